@@ -0,0 +1,308 @@
+package charts
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Size of rendered charts in pixels.
+const (
+	Width  = 720
+	Height = 440
+)
+
+// plot area margins, the right one leaves room for the legend
+const (
+	marginLeft   = 80
+	marginRight  = 140
+	marginTop    = 40
+	marginBottom = 50
+)
+
+// maxTicks bounds the tick marks of an axis, nice steps give far fewer.
+const maxTicks = 20
+
+var (
+	background = color.RGBA{255, 255, 255, 255}
+	foreground = color.RGBA{33, 33, 33, 255}
+	gridColor  = color.RGBA{224, 224, 224, 255}
+	barColor   = color.RGBA{38, 166, 154, 255}
+)
+
+// Palette colours series in order.
+var Palette = []color.RGBA{
+	{41, 128, 185, 255},
+	{230, 126, 34, 255},
+	{39, 174, 96, 255},
+	{142, 68, 173, 255},
+	{192, 57, 43, 255},
+}
+
+// Format is an image format charts are rendered to.
+type Format string
+
+const (
+	SVG Format = "svg"
+	PNG Format = "png"
+)
+
+// ErrUnknownFormat is returned by ParseFormat for formats other than svg and png.
+var ErrUnknownFormat = errors.New("unknown chart format")
+
+// ParseFormat reads a format name, case insensitively.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case SVG, PNG:
+		return format, nil
+	}
+	return "", fmt.Errorf("%w %s", ErrUnknownFormat, name)
+}
+
+// ContentType is the media type of images in the format.
+func (format Format) ContentType() string {
+	if format == PNG {
+		return "image/png"
+	}
+	return "image/svg+xml"
+}
+
+type Point struct {
+	X, Y float64
+}
+
+// Series is a named set of points, joined by lines or drawn as dots.
+type Series struct {
+	Name   string
+	Color  color.RGBA
+	Points []Point
+	Lines  bool
+}
+
+// Bar is a histogram bin counting the values in [From, To).
+type Bar struct {
+	From, To float64
+	Count    int
+}
+
+// Chart is a two dimensional chart with labelled axes, drawn as series of points or as histogram bars.
+type Chart struct {
+	Title  string
+	XLabel string
+	YLabel string
+	Series []Series
+	// Bars replace the series when set.
+	Bars []Bar
+}
+
+// Histogram counts values in bins of equal width spanning their range, the last bin includes the maximum.
+func Histogram(values []float64, bins int) []Bar {
+	if len(values) == 0 || bins <= 0 {
+		return nil
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low, high = math.Min(low, value), math.Max(high, value)
+	}
+	if low == high {
+		low, high = low-0.5, high+0.5
+	}
+
+	width := (high - low) / float64(bins)
+	result := make([]Bar, bins)
+	for i := range result {
+		result[i] = Bar{From: low + float64(i)*width, To: low + float64(i+1)*width}
+	}
+	result[bins-1].To = high
+	for _, value := range values {
+		bin := min(int((value-low)/width), bins-1)
+		result[bin].Count++
+	}
+	return result
+}
+
+// Render draws the chart in the given format.
+func (chart Chart) Render(w io.Writer, format Format) error {
+	switch format {
+	case SVG:
+		canvas := newSVGCanvas()
+		chart.draw(canvas)
+		return canvas.encode(w)
+	case PNG:
+		canvas := newPNGCanvas()
+		chart.draw(canvas)
+		return canvas.encode(w)
+	}
+	return fmt.Errorf("%w %s", ErrUnknownFormat, format)
+}
+
+type anchor int
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// canvas is the drawing surface shared by the SVG and PNG renderers, coordinates are in pixels from the top left.
+type canvas interface {
+	line(x1, y1, x2, y2 float64, stroke color.RGBA, width float64)
+	circle(cx, cy, r float64, fill color.RGBA)
+	rect(x, y, width, height float64, fill color.RGBA)
+	// text draws s with its baseline at y, aligned on x as told by anchor.
+	text(x, y float64, s string, align anchor, fill color.RGBA)
+}
+
+// axis maps data values to pixels along one direction.
+type axis struct {
+	low, high  float64
+	from, to   float64
+	ticks      []float64
+	tickFormat func(float64) string
+}
+
+func (a axis) scale(value float64) float64 {
+	return a.from + (value-a.low)/(a.high-a.low)*(a.to-a.from)
+}
+
+func (chart Chart) draw(c canvas) {
+	c.rect(0, 0, Width, Height, background)
+	left, right := float64(marginLeft), float64(Width-marginRight)
+	top, bottom := float64(marginTop), float64(Height-marginBottom)
+
+	xs, ys := chart.values()
+	x := newAxis(xs, left, right, false)
+	y := newAxis(ys, bottom, top, len(chart.Bars) > 0)
+
+	for _, tick := range y.ticks {
+		position := y.scale(tick)
+		c.line(left, position, right, position, gridColor, 1)
+		c.text(left-8, position+4, y.tickFormat(tick), anchorEnd, foreground)
+	}
+	for _, tick := range x.ticks {
+		position := x.scale(tick)
+		c.line(position, bottom, position, bottom+5, foreground, 1)
+		c.text(position, bottom+20, x.tickFormat(tick), anchorMiddle, foreground)
+	}
+	c.line(left, bottom, right, bottom, foreground, 1)
+	c.line(left, top, left, bottom, foreground, 1)
+
+	for _, bar := range chart.Bars {
+		from, to := x.scale(bar.From), x.scale(bar.To)
+		height := bottom - y.scale(float64(bar.Count))
+		c.rect(from+1, bottom-height, math.Max(to-from-2, 1), height, barColor)
+	}
+	if len(chart.Bars) == 0 {
+		for _, series := range chart.Series {
+			for i, point := range series.Points {
+				if series.Lines && i > 0 {
+					previous := series.Points[i-1]
+					c.line(x.scale(previous.X), y.scale(previous.Y), x.scale(point.X), y.scale(point.Y), series.Color, 2)
+				}
+				c.circle(x.scale(point.X), y.scale(point.Y), 3.5, series.Color)
+			}
+		}
+	}
+	if len(xs) == 0 {
+		c.text((left+right)/2, (top+bottom)/2, "No data", anchorMiddle, foreground)
+	}
+
+	legend := top + 10
+	for _, series := range chart.Series {
+		if series.Name == "" {
+			continue
+		}
+		c.rect(right+16, legend-8, 10, 10, series.Color)
+		c.text(right+32, legend+1, series.Name, anchorStart, foreground)
+		legend += 20
+	}
+
+	c.text(Width/2, 24, chart.Title, anchorMiddle, foreground)
+	c.text((left+right)/2, Height-12, chart.XLabel, anchorMiddle, foreground)
+	c.text(left, top-10, chart.YLabel, anchorMiddle, foreground)
+}
+
+// values lists the x and y values the axes must span.
+func (chart Chart) values() ([]float64, []float64) {
+	var xs, ys []float64
+	if len(chart.Bars) > 0 {
+		for _, bar := range chart.Bars {
+			xs = append(xs, bar.From, bar.To)
+			ys = append(ys, float64(bar.Count))
+		}
+		return xs, ys
+	}
+	for _, series := range chart.Series {
+		for _, point := range series.Points {
+			xs = append(xs, point.X)
+			ys = append(ys, point.Y)
+		}
+	}
+	return xs, ys
+}
+
+// newAxis spans values with round tick marks, from zero when fromZero is set.
+func newAxis(values []float64, from float64, to float64, fromZero bool) axis {
+	low, high := 0.0, 1.0
+	if len(values) > 0 {
+		low, high = values[0], values[0]
+		for _, value := range values {
+			low, high = math.Min(low, value), math.Max(high, value)
+		}
+	}
+	if fromZero {
+		low = math.Min(low, 0)
+	}
+	if low == high {
+		padding := math.Max(math.Abs(low)*0.1, 1)
+		low, high = low-padding, high+padding
+	}
+
+	step := niceStep((high - low) / 5)
+	a := axis{low: math.Floor(low/step) * step, high: math.Ceil(high/step) * step, from: from, to: to}
+	ticks := math.Min(math.Floor((a.high-a.low)/step+0.5)+1, maxTicks)
+	for i := 0.0; i < ticks; i++ {
+		tick := a.low + i*step
+		// a step below the precision of the values no longer moves the ticks
+		if len(a.ticks) > 0 && tick == a.ticks[len(a.ticks)-1] {
+			break
+		}
+		a.ticks = append(a.ticks, tick)
+	}
+	a.tickFormat = tickFormat(step)
+	return a
+}
+
+// niceStep rounds a raw tick spacing up to 1, 2 or 5 times a power of ten.
+func niceStep(raw float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, multiple := range []float64{1, 2, 5} {
+		if raw <= multiple*magnitude {
+			return multiple * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// tickFormat prints ticks with the decimals the step needs, and large values in thousands or millions.
+func tickFormat(step float64) func(float64) string {
+	return func(value float64) string {
+		for _, unit := range []struct {
+			size   float64
+			suffix string
+		}{{1e9, "G"}, {1e6, "M"}, {1e3, "k"}} {
+			if step >= unit.size/10 && math.Abs(value) >= unit.size {
+				return strconv.FormatFloat(value/unit.size, 'f', decimals(step/unit.size), 64) + unit.suffix
+			}
+		}
+		return strconv.FormatFloat(value, 'f', decimals(step), 64)
+	}
+}
+
+func decimals(step float64) int {
+	return max(0, int(math.Ceil(-math.Log10(step)-1e-9)))
+}
@@ -0,0 +1,70 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	bars := Histogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}, 5)
+	require.Len(t, bars, 5)
+	assert.Equal(t, Bar{From: 0, To: 2, Count: 2}, bars[0])
+	assert.Equal(t, Bar{From: 8, To: 10, Count: 2}, bars[4], "the maximum falls in the last bin")
+
+	single := Histogram([]float64{3, 3}, 2)
+	assert.Equal(t, []Bar{{From: 2.5, To: 3, Count: 0}, {From: 3, To: 3.5, Count: 2}}, single)
+	assert.Nil(t, Histogram(nil, 4))
+}
+
+func TestAxisTicks(t *testing.T) {
+	a := newAxis([]float64{0.3, 9.2}, 0, 100, false)
+	assert.Equal(t, []float64{0, 2, 4, 6, 8, 10}, a.ticks)
+	assert.Equal(t, 0.0, a.scale(0))
+	assert.Equal(t, 100.0, a.scale(10))
+
+	// values far larger than their spread, the step is below their precision
+	huge := newAxis([]float64{1e17, 1e17 + 16}, 0, 100, false)
+	assert.NotEmpty(t, huge.ticks)
+	assert.LessOrEqual(t, len(huge.ticks), maxTicks)
+
+	assert.Equal(t, "1.5M", tickFormat(500000)(1500000))
+	assert.Equal(t, "0.25", tickFormat(0.05)(0.25))
+	assert.Equal(t, "40", tickFormat(20)(40))
+}
+
+func TestRender(t *testing.T) {
+	chart := Chart{
+		Title:  "Fuel <cost>",
+		XLabel: "Crew",
+		YLabel: "Fuel",
+		Series: []Series{{Name: "Jupiter", Color: Palette[0], Lines: true, Points: []Point{{1, 10}, {2, 20}, {3, 30}}}},
+	}
+
+	var svg bytes.Buffer
+	require.NoError(t, chart.Render(&svg, SVG))
+	assert.NoError(t, xml.Unmarshal(svg.Bytes(), new(struct{})), "the SVG is well formed")
+	assert.Contains(t, svg.String(), "Fuel &lt;cost&gt;")
+	assert.Contains(t, svg.String(), ">Jupiter</text>")
+
+	var image bytes.Buffer
+	require.NoError(t, chart.Render(&image, PNG))
+	decoded, err := png.Decode(&image)
+	require.NoError(t, err)
+	assert.Equal(t, Width, decoded.Bounds().Dx())
+	assert.Equal(t, Height, decoded.Bounds().Dy())
+
+	var empty bytes.Buffer
+	require.NoError(t, Chart{}.Render(&empty, SVG))
+	assert.Contains(t, empty.String(), "No data")
+
+	_, err = ParseFormat("gif")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	format, err := ParseFormat("PNG")
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", format.ContentType())
+}
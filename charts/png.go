@@ -0,0 +1,75 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// pngCanvas rasterises onto an RGBA image with the 7x13 bitmap font, keeping the renderer free of cgo and font files.
+type pngCanvas struct {
+	image *image.RGBA
+}
+
+func newPNGCanvas() *pngCanvas {
+	return &pngCanvas{image: image.NewRGBA(image.Rect(0, 0, Width, Height))}
+}
+
+// line steps along the longer direction one pixel at a time, stamping a square brush of the given width.
+func (c *pngCanvas) line(x1, y1, x2, y2 float64, stroke color.RGBA, width float64) {
+	steps := math.Max(math.Abs(x2-x1), math.Abs(y2-y1))
+	brush := math.Max(math.Round(width), 1)
+	for i := 0.0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = i / steps
+		}
+		x, y := x1+(x2-x1)*t, y1+(y2-y1)*t
+		c.fill(image.Rect(int(x-brush/2+0.5), int(y-brush/2+0.5), int(x+brush/2+0.5), int(y+brush/2+0.5)), stroke)
+	}
+}
+
+func (c *pngCanvas) circle(cx, cy, r float64, fill color.RGBA) {
+	for y := math.Floor(cy - r); y <= cy+r; y++ {
+		for x := math.Floor(cx - r); x <= cx+r; x++ {
+			if dx, dy := x+0.5-cx, y+0.5-cy; dx*dx+dy*dy <= r*r {
+				c.image.SetRGBA(int(x), int(y), fill)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) rect(x, y, width, height float64, fill color.RGBA) {
+	c.fill(image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+width)), int(math.Round(y+height))), fill)
+}
+
+func (c *pngCanvas) fill(area image.Rectangle, fill color.RGBA) {
+	if area.Empty() {
+		area.Max = area.Min.Add(image.Pt(1, 1))
+	}
+	draw.Draw(c.image, area, image.NewUniform(fill), image.Point{}, draw.Src)
+}
+
+func (c *pngCanvas) text(x, y float64, s string, align anchor, fill color.RGBA) {
+	drawer := font.Drawer{Dst: c.image, Src: image.NewUniform(fill), Face: basicfont.Face7x13}
+	width := drawer.MeasureString(s)
+	switch align {
+	case anchorMiddle:
+		x -= float64(width.Round()) / 2
+	case anchorEnd:
+		x -= float64(width.Round())
+	}
+	drawer.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	drawer.DrawString(s)
+}
+
+func (c *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, c.image)
+}
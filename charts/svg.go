@@ -0,0 +1,50 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// svgCanvas collects SVG elements, written out with the document header by encode.
+type svgCanvas struct {
+	body bytes.Buffer
+}
+
+func newSVGCanvas() *svgCanvas {
+	return &svgCanvas{}
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2 float64, stroke color.RGBA, width float64) {
+	fmt.Fprintf(&c.body, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%g"/>`+"\n", x1, y1, x2, y2, hex(stroke), width)
+}
+
+func (c *svgCanvas) circle(cx, cy, r float64, fill color.RGBA) {
+	fmt.Fprintf(&c.body, `<circle cx="%.1f" cy="%.1f" r="%g" fill="%s"/>`+"\n", cx, cy, r, hex(fill))
+}
+
+func (c *svgCanvas) rect(x, y, width, height float64, fill color.RGBA) {
+	fmt.Fprintf(&c.body, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, width, height, hex(fill))
+}
+
+func (c *svgCanvas) text(x, y float64, s string, align anchor, fill color.RGBA) {
+	if s == "" {
+		return
+	}
+	anchors := [...]string{anchorStart: "start", anchorMiddle: "middle", anchorEnd: "end"}
+	fmt.Fprintf(&c.body, `<text x="%.1f" y="%.1f" text-anchor="%s" fill="%s">`, x, y, anchors[align], hex(fill))
+	xml.EscapeText(&c.body, []byte(s))
+	c.body.WriteString("</text>\n")
+}
+
+func (c *svgCanvas) encode(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n%s</svg>\n",
+		Width, Height, Width, Height, c.body.Bytes())
+	return err
+}
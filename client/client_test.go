@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/routes"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupServer(t *testing.T) *httptest.Server {
+	db, err := gorm.Open(database.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	// a private in-memory database lives as long as its single connection
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.RegisterRoutes(router, db)
+	server := httptest.NewServer(router)
+	t.Cleanup(func() {
+		server.Close()
+		sqlDB.Close()
+	})
+	return server
+}
+
+func newPlanet(name string, distance int64, radius float64, planetType models.PlanetType) models.Planet {
+	return models.Planet{Name: name, Description: "A planet", Distance: distance, Radius: radius, Mass: 2, Type: planetType}
+}
+
+func TestPlanetLifecycle(t *testing.T) {
+	server := setupServer(t)
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := c.CreatePlanet(ctx, newPlanet("Pluto", 50, 2, models.Terrestrial))
+	if err != nil {
+		t.Fatalf("Failed to create planet: %v", err)
+	}
+	assert.NotZero(t, created.ID)
+
+	_, err = c.CreatePlanet(ctx, newPlanet("Pluto", 60, 2, models.Terrestrial))
+	assert.ErrorIs(t, err, ErrConflict)
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "conflict", apiErr.Problem.Code)
+	}
+
+	_, err = c.CreatePlanet(ctx, newPlanet("Vulcan", 5000, 2, models.Terrestrial))
+	assert.ErrorIs(t, err, ErrValidation)
+
+	planet, err := c.GetPlanet(ctx, created.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Pluto", planet.Name)
+	}
+
+	cost, err := c.FuelCost(ctx, created.ID, 10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2000.0, cost)
+	}
+
+	updated := newPlanet("Charon", 80, 2, models.Terrestrial)
+	assert.NoError(t, c.UpdatePlanet(ctx, created.ID, updated))
+	planet, err = c.GetPlanet(ctx, created.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Charon", planet.Name)
+	}
+
+	assert.NoError(t, c.DeletePlanet(ctx, created.ID))
+	_, err = c.GetPlanet(ctx, created.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, c.DeletePlanet(ctx, created.ID), ErrNotFound)
+}
+
+func TestListAndIteratePlanets(t *testing.T) {
+	server := setupServer(t)
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	for i, name := range []string{"Aegir", "Bellerophon", "Cancri", "Dagon", "Enaiposha"} {
+		planetType := models.Terrestrial
+		if i%2 == 0 {
+			planetType = models.GasGiant
+		}
+		if _, err := c.CreatePlanet(ctx, newPlanet(name, int64(100+i*100), 1+float64(i), planetType)); err != nil {
+			t.Fatalf("Failed to seed planet: %v", err)
+		}
+	}
+
+	page, err := c.ListPlanets(ctx, queryoperations.QueryParams{
+		Sort:    "distance desc",
+		Filters: map[string]queryoperations.FilterParam{"distance": {Gte: 200}, "type": {Eq: "gas_giant"}},
+	})
+	if assert.NoError(t, err) && assert.Len(t, page.Planets, 2) {
+		assert.Equal(t, "Enaiposha", page.Planets[0].Name)
+		assert.Equal(t, "Cancri", page.Planets[1].Name)
+	}
+
+	_, err = c.ListPlanets(ctx, queryoperations.QueryParams{Sort: "distance; DROP TABLE planets"})
+	assert.ErrorIs(t, err, ErrBadRequest)
+
+	var names []string
+	it := c.IteratePlanets(queryoperations.QueryParams{Sort: "name", Limit: 2})
+	for it.Next(ctx) {
+		names = append(names, it.Planet().Name)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"Aegir", "Bellerophon", "Cancri", "Dagon", "Enaiposha"}, names)
+
+	planets, err := c.AllPlanets(ctx, queryoperations.QueryParams{Filters: map[string]queryoperations.FilterParam{"name": {Like: "a"}}, Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, planets, 4)
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status": 200, "data": {"ID": 7, "name": "Pluto"}}`))
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetries(2, time.Millisecond))
+	planet, err := c.GetPlanet(context.Background(), 7)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Pluto", planet.Name)
+	}
+	assert.Equal(t, int32(3), calls.Load())
+
+	// creating is not idempotent and is never retried
+	calls.Store(0)
+	_, err = c.CreatePlanet(context.Background(), newPlanet("Pluto", 50, 2, models.Terrestrial))
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// retries give up once exhausted
+	calls.Store(-10)
+	_, err = c.GetPlanet(context.Background(), 7)
+	assert.True(t, errors.Is(err, ErrUnavailable))
+	assert.Equal(t, int32(-7), calls.Load())
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+)
+
+// Sentinel errors matched by APIError through errors.Is.
+var (
+	ErrBadRequest  = errors.New("bad request")
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("service unavailable")
+)
+
+// APIError is returned for every non-2xx response, carrying the server's problem details.
+type APIError struct {
+	StatusCode int
+	Problem    problems.Problem
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+	if err := json.Unmarshal(body, &apiErr.Problem); err != nil || apiErr.Problem.Status == 0 {
+		// not a problem document, e.g. an error page from a proxy
+		apiErr.Problem = problems.Problem{Status: statusCode, Title: http.StatusText(statusCode), Detail: string(body)}
+	}
+	return apiErr
+}
+
+func (err *APIError) Error() string {
+	if err.Problem.Detail != "" {
+		return fmt.Sprintf("%d %s: %s", err.StatusCode, err.Problem.Title, err.Problem.Detail)
+	}
+	return fmt.Sprintf("%d %s", err.StatusCode, http.StatusText(err.StatusCode))
+}
+
+func (err *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return err.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return err.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return err.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return err.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnavailable:
+		return err.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
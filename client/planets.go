@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+)
+
+// DefaultPageSize is used by IteratePlanets when the params do not set a limit.
+const DefaultPageSize = 100
+
+// PlanetPage is one page of a planet listing.
+type PlanetPage struct {
+	Planets []models.Planet `json:"data"`
+	Total   int             `json:"total"`
+	Page    int             `json:"page"`
+	Limit   int             `json:"limit"`
+}
+
+// ListPlanets fetches the planets matching params, filters are encoded with the filter[field]=<json> syntax.
+func (c *Client) ListPlanets(ctx context.Context, params queryoperations.QueryParams) (*PlanetPage, error) {
+	query, err := params.Values()
+	if err != nil {
+		return nil, err
+	}
+	var page PlanetPage
+	if err := c.do(ctx, http.MethodGet, "/planets", query, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetPlanet fetches a planet by id.
+func (c *Client) GetPlanet(ctx context.Context, id uint) (*models.Planet, error) {
+	var response struct {
+		Data models.Planet `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, planetPath(id), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// CreatePlanet adds a planet to the catalogue and returns it as stored.
+func (c *Client) CreatePlanet(ctx context.Context, planet models.Planet) (*models.Planet, error) {
+	var response struct {
+		Planet models.Planet `json:"planet"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/planets", nil, planet, &response); err != nil {
+		return nil, err
+	}
+	return &response.Planet, nil
+}
+
+// UpdatePlanet replaces the details of the planet with the given id.
+func (c *Client) UpdatePlanet(ctx context.Context, id uint, planet models.Planet) error {
+	return c.do(ctx, http.MethodPut, planetPath(id), nil, planet, nil)
+}
+
+// DeletePlanet removes the planet with the given id.
+func (c *Client) DeletePlanet(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, planetPath(id), nil, nil, nil)
+}
+
+// FuelCost estimates the fuel needed to take a crew of the given size to the planet.
+func (c *Client) FuelCost(ctx context.Context, id uint, crew int64) (float64, error) {
+	var response struct {
+		Data float64 `json:"data"`
+	}
+	body := map[string]int64{"Capacity": crew}
+	if err := c.do(ctx, http.MethodGet, "/planets/getFuelCost/"+strconv.FormatUint(uint64(id), 10), nil, body, &response); err != nil {
+		return 0, err
+	}
+	return response.Data, nil
+}
+
+func planetPath(id uint) string {
+	return "/planets/" + url.PathEscape(strconv.FormatUint(uint64(id), 10))
+}
+
+// PlanetIterator walks every planet matching a listing, one page at a time.
+type PlanetIterator struct {
+	client  *Client
+	params  queryoperations.QueryParams
+	buffer  []models.Planet
+	current models.Planet
+	done    bool
+	err     error
+}
+
+// IteratePlanets returns an iterator over all planets matching params, starting at params.Page (default 1)
+// and requesting params.Limit planets per page (default DefaultPageSize).
+func (c *Client) IteratePlanets(params queryoperations.QueryParams) *PlanetIterator {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.Limit <= 0 {
+		params.Limit = DefaultPageSize
+	}
+	return &PlanetIterator{client: c, params: params}
+}
+
+// Next advances to the next planet, fetching the following page when needed.
+// It returns false when all planets have been read or an error occurred.
+func (it *PlanetIterator) Next(ctx context.Context) bool {
+	for len(it.buffer) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, err := it.client.ListPlanets(ctx, it.params)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.buffer = page.Planets
+		// a short page is the last one
+		it.done = len(page.Planets) < it.params.Limit
+		it.params.Page++
+	}
+	it.current, it.buffer = it.buffer[0], it.buffer[1:]
+	return true
+}
+
+// Planet returns the planet the iterator is positioned on.
+func (it *PlanetIterator) Planet() models.Planet {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PlanetIterator) Err() error {
+	return it.err
+}
+
+// AllPlanets collects every planet matching params by iterating over all pages.
+func (c *Client) AllPlanets(ctx context.Context, params queryoperations.QueryParams) ([]models.Planet, error) {
+	var planets []models.Planet
+	it := c.IteratePlanets(params)
+	for it.Next(ctx) {
+		planets = append(planets, it.Planet())
+	}
+	return planets, it.Err()
+}
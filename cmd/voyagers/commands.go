@@ -0,0 +1,452 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+func (a *app) planets(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("planets: missing subcommand")
+	}
+	switch args[0] {
+	case "list":
+		return a.listPlanets(ctx, args[1:])
+	case "get":
+		return a.getPlanet(ctx, args[1:])
+	case "create":
+		return a.createPlanet(ctx, args[1:])
+	case "update":
+		return a.updatePlanet(ctx, args[1:])
+	case "delete":
+		return a.deletePlanet(ctx, args[1:])
+	}
+	return usageError("planets: unknown subcommand %q", args[0])
+}
+
+func buildParams(filters stringList, sort string) (queryoperations.QueryParams, error) {
+	params := queryoperations.QueryParams{Sort: parseSort(sort), Filters: map[string]queryoperations.FilterParam{}}
+	for _, expression := range filters {
+		if err := parseFilter(expression, params.Filters); err != nil {
+			return params, usageError("%v", err)
+		}
+	}
+	return params, nil
+}
+
+func (a *app) listPlanets(ctx context.Context, args []string) error {
+	flags := a.flags("planets list")
+	var filters stringList
+	var sort string
+	flags.Var(&filters, "filter", "")
+	flags.StringVar(&sort, "sort", "", "")
+	page := flags.Int("page", 0, "")
+	limit := flags.Int("limit", 0, "")
+	all := flags.Bool("all", false, "")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	p, err := a.printer()
+	if err != nil {
+		return err
+	}
+	params, err := buildParams(filters, sort)
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if *all {
+		params.Limit = *limit
+		planets, err := c.AllPlanets(ctx, params)
+		if err != nil {
+			return err
+		}
+		return p.planets(planets)
+	}
+
+	params.Page, params.Limit = *page, *limit
+	result, err := c.ListPlanets(ctx, params)
+	if err != nil {
+		return err
+	}
+	return p.planets(result.Planets)
+}
+
+func (a *app) getPlanet(ctx context.Context, args []string) error {
+	flags := a.flags("planets get")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	id, err := singleID(flags.Name(), positional)
+	if err != nil {
+		return err
+	}
+	p, err := a.printer()
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	planet, err := c.GetPlanet(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.planet(*planet)
+}
+
+func (a *app) createPlanet(ctx context.Context, args []string) error {
+	flags := a.flags("planets create")
+	file := flags.String("f", "", "")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageError("planets create: -f FILE is required")
+	}
+	p, err := a.printer()
+	if err != nil {
+		return err
+	}
+	var planet models.Planet
+	if err := a.readFile(*file, &planet); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	created, err := c.CreatePlanet(ctx, planet)
+	if err != nil {
+		return err
+	}
+	return p.planet(*created)
+}
+
+func (a *app) updatePlanet(ctx context.Context, args []string) error {
+	flags := a.flags("planets update")
+	file := flags.String("f", "", "")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	id, err := singleID(flags.Name(), positional)
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageError("planets update: -f FILE is required")
+	}
+	var planet models.Planet
+	if err := a.readFile(*file, &planet); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := c.UpdatePlanet(ctx, id, planet); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Planet %d updated.\n", id)
+	return nil
+}
+
+func (a *app) deletePlanet(ctx context.Context, args []string) error {
+	flags := a.flags("planets delete")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	id, err := singleID(flags.Name(), positional)
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := c.DeletePlanet(ctx, id); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Planet %d deleted.\n", id)
+	return nil
+}
+
+func (a *app) fuelCost(ctx context.Context, args []string) error {
+	flags := a.flags("fuel-cost")
+	crew := flags.Int64("crew", 0, "")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	id, err := singleID(flags.Name(), positional)
+	if err != nil {
+		return err
+	}
+	if *crew <= 0 {
+		return usageError("fuel-cost: --crew must be a positive number")
+	}
+	p, err := a.printer()
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	cost, err := c.FuelCost(ctx, id, *crew)
+	if err != nil {
+		return err
+	}
+	result := struct {
+		PlanetID uint    `json:"planet_id"`
+		Crew     int64   `json:"crew"`
+		FuelCost float64 `json:"fuel_cost"`
+	}{id, *crew, cost}
+	return p.fields(result, [][2]string{
+		{"PLANET", strconv.FormatUint(uint64(id), 10)},
+		{"CREW", strconv.FormatInt(*crew, 10)},
+		{"FUEL COST", strconv.FormatFloat(cost, 'f', 2, 64)},
+	})
+}
+
+func (a *app) importPlanets(ctx context.Context, args []string) error {
+	flags := a.flags("import")
+	file := flags.String("f", "", "")
+	continueOnError := flags.Bool("continue-on-error", false, "")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageError("import: -f FILE is required")
+	}
+	var planets []models.Planet
+	if err := a.readFile(*file, &planets); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	imported, failed := 0, 0
+	for _, planet := range planets {
+		// ids and timestamps of an export belong to the source catalogue
+		planet.Model = gorm.Model{}
+		if _, err := c.CreatePlanet(ctx, planet); err != nil {
+			failed++
+			fmt.Fprintf(a.stderr, "Could not import %s: %v\n", planet.Name, err)
+			if !*continueOnError {
+				return fmt.Errorf("import stopped after %d planets", imported)
+			}
+			continue
+		}
+		imported++
+	}
+	fmt.Fprintf(a.stdout, "Imported %d planets, %d failed.\n", imported, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d planets could not be imported", failed)
+	}
+	return nil
+}
+
+func (a *app) exportPlanets(ctx context.Context, args []string) error {
+	flags := a.flags("export")
+	file := flags.String("f", "", "")
+	var filters stringList
+	var sort string
+	flags.Var(&filters, "filter", "")
+	flags.StringVar(&sort, "sort", "", "")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	params, err := buildParams(filters, sort)
+	if err != nil {
+		return err
+	}
+
+	// exports must be importable, tables are not
+	format := strings.ToLower(a.output)
+	if format == "table" {
+		format = formatForPath(*file)
+	}
+	var out io.Writer = a.stdout
+	if *file != "" && *file != "-" {
+		handle, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer handle.Close()
+		out = handle
+	}
+	p := printer{out: out, format: format}
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	planets, err := c.AllPlanets(ctx, params)
+	if err != nil {
+		return err
+	}
+	if err := p.planets(planets); err != nil {
+		return err
+	}
+	if out != a.stdout {
+		fmt.Fprintf(a.stdout, "Exported %d planets to %s.\n", len(planets), *file)
+	}
+	return nil
+}
+
+func (a *app) profiles(args []string) error {
+	if len(args) == 0 {
+		return usageError("profiles: missing subcommand")
+	}
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	flags := a.flags("profiles " + args[0])
+	positional, err := parse(flags, args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		p, err := a.printer()
+		if err != nil {
+			return err
+		}
+		// API keys are never printed
+		rows := [][2]string{{"NAME", "SERVER"}}
+		listed := map[string]string{}
+		for _, name := range config.names() {
+			marker := ""
+			if name == config.Current {
+				marker = " (current)"
+			}
+			rows = append(rows, [2]string{name + marker, config.Profiles[name].Server})
+			listed[name] = config.Profiles[name].Server
+		}
+		return p.fields(map[string]interface{}{"current": config.Current, "profiles": listed}, rows)
+	case "set":
+		if len(positional) != 1 {
+			return usageError("profiles set: expected a profile name")
+		}
+		profile := config.Profiles[positional[0]]
+		if a.server != "" {
+			profile.Server = a.server
+		}
+		if a.apiKey != "" {
+			profile.APIKey = a.apiKey
+		}
+		if profile.Server == "" {
+			profile.Server = defaultServer
+		}
+		config.Profiles[positional[0]] = profile
+		if config.Current == "" {
+			config.Current = positional[0]
+		}
+		return config.save()
+	case "use":
+		if len(positional) != 1 {
+			return usageError("profiles use: expected a profile name")
+		}
+		if _, ok := config.Profiles[positional[0]]; !ok {
+			return fmt.Errorf("unknown profile %q", positional[0])
+		}
+		config.Current = positional[0]
+		return config.save()
+	case "delete":
+		if len(positional) != 1 {
+			return usageError("profiles delete: expected a profile name")
+		}
+		delete(config.Profiles, positional[0])
+		if config.Current == positional[0] {
+			config.Current = ""
+		}
+		return config.save()
+	}
+	return usageError("profiles: unknown subcommand %q", args[0])
+}
+
+func singleID(command string, positional []string) (uint, error) {
+	if len(positional) != 1 {
+		return 0, usageError("%s: expected exactly one planet id", command)
+	}
+	id, err := strconv.ParseUint(positional[0], 10, 64)
+	if err != nil || id == 0 {
+		return 0, usageError("%s: invalid planet id %q", command, positional[0])
+	}
+	return uint(id), nil
+}
+
+// readFile decodes a JSON or YAML file ("-" reads standard input) into target.
+func (a *app) readFile(path string, target interface{}) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(a.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if formatForPath(path) == "yaml" {
+		// go through a generic value so YAML documents use the same keys as the JSON API
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return fmt.Errorf("invalid YAML in %s: %w", path, err)
+		}
+		if data, err = json.Marshal(generic); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return nil
+}
+
+func formatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	return "json"
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// Profile holds the connection settings of one API server.
+type Profile struct {
+	Server string `yaml:"server"`
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+// Config is the CLI configuration file, listing profiles and the one used by default.
+type Config struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	path string
+}
+
+// configPath returns $VOYAGERS_CONFIG, or voyagers/config.yaml in the user configuration directory.
+func configPath() (string, error) {
+	if path := os.Getenv("VOYAGERS_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "voyagers", "config.yaml"), nil
+}
+
+// loadConfig reads the configuration file, a missing file yields an empty configuration.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{Profiles: map[string]Profile{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]Profile{}
+	}
+	return config, nil
+}
+
+func (config *Config) save() error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(config.path), 0o700); err != nil {
+		return err
+	}
+	// profiles may hold API keys, keep the file private
+	return os.WriteFile(config.path, data, 0o600)
+}
+
+// resolve picks the profile to use: the named one, else the current one, else the defaults.
+func (config *Config) resolve(name string) (Profile, error) {
+	if name == "" {
+		name = config.Current
+	}
+	if name == "" {
+		return Profile{Server: defaultServer}, nil
+	}
+	profile, ok := config.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q", name)
+	}
+	if profile.Server == "" {
+		profile.Server = defaultServer
+	}
+	return profile, nil
+}
+
+func (config *Config) names() []string {
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+)
+
+// filterOperators lists two-character operators first so that they win over their prefixes at the same position.
+var filterOperators = []string{">=", "<=", "!=", ">", "<", "=", "~"}
+
+// parseFilter turns an expression such as "distance>100", "name~ter" or "type in gas_giant,terrestrial"
+// into the field it applies to and the equivalent filter, merged into filters.
+func parseFilter(expression string, filters map[string]queryoperations.FilterParam) error {
+	if field, values, ok := cutWord(expression, "notin"); ok {
+		filter := filters[field]
+		filter.NotIn = append(filter.NotIn, values...)
+		filters[field] = filter
+		return nil
+	}
+	if field, values, ok := cutWord(expression, "in"); ok {
+		filter := filters[field]
+		filter.In = append(filter.In, values...)
+		filters[field] = filter
+		return nil
+	}
+
+	if field, operator, raw, found := cutOperator(expression); found {
+		field, raw = strings.TrimSpace(field), strings.TrimSpace(raw)
+		if field == "" || raw == "" {
+			return fmt.Errorf("invalid filter %q", expression)
+		}
+
+		filter := filters[field]
+		value := parseValue(raw)
+		switch operator {
+		case ">=":
+			filter.Gte = value
+		case "<=":
+			filter.Lte = value
+		case "!=":
+			filter.Neq = value
+		case ">":
+			filter.Gt = value
+		case "<":
+			filter.Lt = value
+		case "=":
+			filter.Eq = value
+		case "~":
+			filter.Like = raw
+		}
+		filters[field] = filter
+		return nil
+	}
+	return fmt.Errorf("invalid filter %q, expected field<op>value with op one of %s, in or notin", expression, strings.Join(filterOperators, " "))
+}
+
+// cutOperator splits expression around its leftmost operator, so that "name~a>b" matches names like "a>b".
+func cutOperator(expression string) (field string, operator string, raw string, found bool) {
+	at := -1
+	for _, candidate := range filterOperators {
+		if index := strings.Index(expression, candidate); index >= 0 && (at < 0 || index < at) {
+			at, operator = index, candidate
+		}
+	}
+	if at < 0 {
+		return "", "", "", false
+	}
+	return expression[:at], operator, expression[at+len(operator):], true
+}
+
+// cutWord splits "field word a,b,c" into its field and values.
+func cutWord(expression string, word string) (string, []string, bool) {
+	fields := strings.Fields(expression)
+	if len(fields) != 3 || fields[1] != word {
+		return "", nil, false
+	}
+	var values []string
+	for _, value := range strings.Split(fields[2], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return fields[0], values, true
+}
+
+// parseValue keeps numbers numeric so they compare numerically in SQL.
+func parseValue(raw string) interface{} {
+	if integer, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return integer
+	}
+	if float, err := strconv.ParseFloat(raw, 64); err == nil {
+		return float
+	}
+	return strings.Trim(raw, `"'`)
+}
+
+// parseSort converts "-mass,name" into the API's "mass desc,name".
+func parseSort(sort string) string {
+	if sort == "" {
+		return ""
+	}
+	terms := strings.Split(sort, ",")
+	for i, term := range terms {
+		term = strings.TrimSpace(term)
+		if strings.HasPrefix(term, "-") {
+			term = strings.TrimPrefix(term, "-") + " desc"
+		} else {
+			term = strings.TrimPrefix(term, "+")
+		}
+		terms[i] = term
+	}
+	return strings.Join(terms, ",")
+}
@@ -0,0 +1,193 @@
+// Command voyagers manages the planet catalogue through the HTTP API.
+//
+//	voyagers planets list --filter 'distance>100' --sort -mass
+//	voyagers planets create -f planet.json
+//	voyagers fuel-cost 3 --crew 5
+//	voyagers export -f catalogue.json
+//	voyagers import -f catalogue.json
+//	voyagers profiles set prod --server https://voyagers.example --api-key KEY
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/client"
+)
+
+const usage = `Usage: voyagers <command> [flags]
+
+Commands:
+  planets list      [--filter EXPR]... [--sort FIELDS] [--page N --limit N | --all]
+  planets get       <id>
+  planets create    -f FILE
+  planets update    <id> -f FILE
+  planets delete    <id>
+  fuel-cost         <id> --crew N
+  import            -f FILE [--continue-on-error]
+  export            [-f FILE] [--filter EXPR]... [--sort FIELDS]
+  profiles list
+  profiles set      <name> [--server URL] [--api-key KEY]
+  profiles use      <name>
+  profiles delete   <name>
+
+Filters are "field<op>value" with op one of > >= < <= = != ~ (like), or "field in a,b" / "field notin a,b".
+Sort fields are comma separated, prefix with - to sort descending.
+
+Global flags (accepted by every command):
+  --profile NAME    profile to use instead of the current one
+  --server URL      API server, overrides the profile
+  --api-key KEY     API key, overrides the profile
+  -o, --output FMT  table, json or yaml (default table)
+  --timeout DUR     request timeout (default 30s)
+`
+
+// app carries the streams and global options shared by all commands.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	profile string
+	server  string
+	apiKey  string
+	output  string
+	timeout time.Duration
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+
+	var err error
+	switch args[0] {
+	case "planets":
+		err = a.planets(ctx, args[1:])
+	case "fuel-cost":
+		err = a.fuelCost(ctx, args[1:])
+	case "import":
+		err = a.importPlanets(ctx, args[1:])
+	case "export":
+		err = a.exportPlanets(ctx, args[1:])
+	case "profiles":
+		err = a.profiles(args[1:])
+	default:
+		err = usageError("unknown command %q", args[0])
+	}
+
+	if err == nil {
+		return 0
+	}
+	fmt.Fprintln(stderr, "Error:", err)
+	var usageErr errUsage
+	if errors.As(err, &usageErr) {
+		fmt.Fprint(stderr, "\n"+usage)
+		return 2
+	}
+	return 1
+}
+
+type errUsage struct {
+	message string
+}
+
+func (err errUsage) Error() string {
+	return err.message
+}
+
+func usageError(format string, args ...interface{}) error {
+	return errUsage{fmt.Sprintf(format, args...)}
+}
+
+// flags returns a flag set carrying the global flags next to the command's own.
+func (a *app) flags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&a.profile, "profile", "", "")
+	flags.StringVar(&a.server, "server", "", "")
+	flags.StringVar(&a.apiKey, "api-key", "", "")
+	flags.StringVar(&a.output, "output", "table", "")
+	flags.StringVar(&a.output, "o", "table", "")
+	flags.DurationVar(&a.timeout, "timeout", 30*time.Second, "")
+	return flags
+}
+
+// parse parses flags placed anywhere among the positional arguments and returns the positional ones.
+func parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, usageError("%s: %v", flags.Name(), err)
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func (a *app) printer() (printer, error) {
+	p := printer{out: a.stdout, format: strings.ToLower(a.output)}
+	return p, p.validate()
+}
+
+// client builds an API client from the selected profile and flag overrides.
+func (a *app) client() (*client.Client, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := config.resolve(a.profile)
+	if err != nil {
+		return nil, err
+	}
+	if a.server != "" {
+		profile.Server = a.server
+	}
+	if a.apiKey != "" {
+		profile.APIKey = a.apiKey
+	}
+	if profile.APIKey == "" {
+		profile.APIKey = os.Getenv("VOYAGERS_API_KEY")
+	}
+
+	options := []client.Option{client.WithHTTPClient(newHTTPClient(a.timeout))}
+	if profile.APIKey != "" {
+		options = append(options, client.WithAPIKey(profile.APIKey))
+	}
+	return client.New(profile.Server, options...)
+}
+
+// stringList collects a repeatable string flag.
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ", ")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
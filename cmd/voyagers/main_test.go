@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/routes"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expressions []string
+		expected map[string]queryoperations.FilterParam
+		expectedError bool
+	}{
+		{[]string{"distance>100"}, map[string]queryoperations.FilterParam{"distance": {Gt: int64(100)}}, false},
+		{[]string{"distance>=100", "distance<500"}, map[string]queryoperations.FilterParam{"distance": {Gte: int64(100), Lt: int64(500)}}, false},
+		{[]string{"radius <= 2.5"}, map[string]queryoperations.FilterParam{"radius": {Lte: 2.5}}, false},
+		{[]string{"type=gas_giant", "name~ter"}, map[string]queryoperations.FilterParam{"type": {Eq: "gas_giant"}, "name": {Like: "ter"}}, false},
+		{[]string{"name~a>b", "description=x<=y"}, map[string]queryoperations.FilterParam{"name": {Like: "a>b"}, "description": {Eq: "x<=y"}}, false},
+		{[]string{"type!=terrestrial"}, map[string]queryoperations.FilterParam{"type": {Neq: "terrestrial"}}, false},
+		{[]string{"type in gas_giant,terrestrial"}, map[string]queryoperations.FilterParam{"type": {In: []string{"gas_giant", "terrestrial"}}}, false},
+		{[]string{"name notin Pluto"}, map[string]queryoperations.FilterParam{"name": {NotIn: []string{"Pluto"}}}, false},
+		{[]string{"distance"}, nil, true},
+		{[]string{">100"}, nil, true},
+	}
+
+	for _, test := range tests {
+		filters := map[string]queryoperations.FilterParam{}
+		var err error
+		for _, expression := range test.expressions {
+			if err = parseFilter(expression, filters); err != nil {
+				break
+			}
+		}
+		if test.expectedError {
+			assert.Error(t, err, test.expressions)
+			continue
+		}
+		assert.NoError(t, err, test.expressions)
+		assert.Equal(t, test.expected, filters, test.expressions)
+	}
+
+	assert.Equal(t, "mass desc,name", parseSort("-mass,name"))
+	assert.Equal(t, "", parseSort(""))
+}
+
+func setupServer(t *testing.T) string {
+	db, err := gorm.Open(database.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.RegisterRoutes(router, db)
+	server := httptest.NewServer(router)
+	t.Cleanup(func() {
+		server.Close()
+		sqlDB.Close()
+	})
+
+	t.Setenv("VOYAGERS_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	return server.URL
+}
+
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	server := setupServer(t)
+
+	code, _, stderr := runCLI(t, "", "profiles", "set", "local", "--server", server, "--api-key", "secret")
+	assert.Equal(t, 0, code, stderr)
+	code, stdout, _ := runCLI(t, "", "profiles", "list")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "local (current)")
+	assert.NotContains(t, stdout, "secret")
+
+	planets := `[
+		{"name": "Jupiter", "description": "A far away planet", "distance": 20, "radius": 9, "mass": 8, "type": "gas_giant"},
+		{"name": "Pluto", "description": "A small planet", "distance": 50, "radius": 2, "mass": 2, "type": "terrestrial"},
+		{"name": "Kepler", "description": "A heavy planet", "distance": 600, "radius": 3, "mass": 9, "type": "terrestrial"}
+	]`
+	code, stdout, stderr = runCLI(t, planets, "import", "-f", "-")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Imported 3 planets, 0 failed.")
+
+	code, stdout, _ = runCLI(t, "", "planets", "list", "--filter", "distance>30", "--sort", "-mass", "-o", "json")
+	assert.Equal(t, 0, code)
+	var listed []models.Planet
+	if assert.NoError(t, json.Unmarshal([]byte(stdout), &listed)) && assert.Len(t, listed, 2) {
+		assert.Equal(t, "Kepler", listed[0].Name)
+		assert.Equal(t, "Pluto", listed[1].Name)
+	}
+
+	code, stdout, _ = runCLI(t, "", "planets", "list", "--all", "--limit", "1", "--sort", "name")
+	assert.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if assert.Len(t, lines, 4) {
+		assert.True(t, strings.HasPrefix(lines[0], "ID"))
+		assert.Contains(t, lines[1], "Jupiter")
+	}
+
+	code, stdout, _ = runCLI(t, "", "fuel-cost", "2", "--crew", "10")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "2000.00")
+
+	code, stdout, _ = runCLI(t, "", "planets", "get", "2", "-o", "yaml")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "name: Pluto")
+
+	code, _, stderr = runCLI(t, "", "planets", "get", "99")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "Could not find planet 99.")
+
+	code, _, stderr = runCLI(t, `{"name": "Pluto", "description": "Again", "distance": 40, "radius": 2, "mass": 2, "type": "terrestrial"}`, "planets", "create", "-f", "-")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "already exists")
+
+	export := filepath.Join(t.TempDir(), "catalogue.yaml")
+	code, stdout, stderr = runCLI(t, "", "export", "-f", export, "--filter", "type=terrestrial")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Exported 2 planets")
+	data, err := os.ReadFile(export)
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(data), "name: Kepler")
+	}
+
+	code, stdout, _ = runCLI(t, "", "planets", "delete", "3")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Planet 3 deleted.")
+
+	code, _, stderr = runCLI(t, "", "planets", "list", "--filter", "distance")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "invalid filter")
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"gopkg.in/yaml.v3"
+)
+
+// printer renders command results in the output format chosen with --output.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func (p printer) validate() error {
+	switch p.format {
+	case "table", "json", "yaml":
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected table, json or yaml", p.format)
+}
+
+// structured writes value as JSON or YAML, YAML keys follow the JSON field names of the API.
+func (p printer) structured(value interface{}) error {
+	if p.format == "yaml" {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(p.out)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(generic)
+	}
+	encoder := json.NewEncoder(p.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func (p printer) planets(planets []models.Planet) error {
+	if p.format != "table" {
+		if planets == nil {
+			planets = []models.Planet{}
+		}
+		return p.structured(planets)
+	}
+	writer := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tNAME\tTYPE\tDISTANCE\tRADIUS\tMASS")
+	for _, planet := range planets {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%d\t%s\t%s\n", planet.ID, planet.Name, planet.Type, planet.Distance,
+			strconv.FormatFloat(planet.Radius, 'g', -1, 64), strconv.FormatFloat(planet.Mass, 'g', -1, 64))
+	}
+	return writer.Flush()
+}
+
+func (p printer) planet(planet models.Planet) error {
+	if p.format != "table" {
+		return p.structured(planet)
+	}
+	return p.planets([]models.Planet{planet})
+}
+
+// fields renders ordered key/value pairs as a two column table.
+func (p printer) fields(value interface{}, rows [][2]string) error {
+	if p.format != "table" {
+		return p.structured(value)
+	}
+	writer := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintf(writer, "%s\t%s\n", row[0], row[1])
+	}
+	return writer.Flush()
+}
@@ -1,11 +1,12 @@
 package database
 
 import (
-	"log"
+	"time"
 
-	"github.com/kaitou-1412/Go-Space-Voyagers/models"
-	_ "github.com/mattn/go-sqlite3"
-	"gorm.io/driver/sqlite"
+	"github.com/kaitou-1412/Go-Space-Voyagers/initialize"
+	"github.com/kaitou-1412/Go-Space-Voyagers/logging"
+	"github.com/kaitou-1412/Go-Space-Voyagers/metrics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/tracing"
 	"gorm.io/gorm"
 )
 
@@ -13,15 +14,27 @@ var DB *gorm.DB
 
 func ConnectToDB() {
 	var err error
-	DB, err = gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
+	DB, err = gorm.Open(Open("gorm.db"), &gorm.Config{
+		Logger: logging.NewGormLogger(initialize.GetEnvDuration("LOG_SLOW_QUERY_THRESHOLD", 200*time.Millisecond)),
+	})
 	if err != nil {
-		log.Fatal("Could not connect to database.")
+		logging.Fatal("Could not connect to database.", "error", err)
 	}
 
-	err = DB.AutoMigrate(&models.Planet{}) 
+	err = DB.Use(metrics.GormPlugin{})
+	if err != nil {
+		logging.Fatal("Could not register database metrics.", "error", err)
+	}
+
+	err = DB.Use(tracing.GormPlugin{})
+	if err != nil {
+		logging.Fatal("Could not register database tracing.", "error", err)
+	}
+
+	err = DB.AutoMigrate(Models...)
 
 	if err != nil {
-		log.Fatal("Migration failure.")
+		logging.Fatal("Migration failure.", "error", err)
 	}
 }
 
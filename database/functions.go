@@ -0,0 +1,37 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/physics"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// DriverName is the sqlite driver with the physics functions used by the derived planet fields.
+const DriverName = "sqlite3_voyagers"
+
+// functions are registered on every connection, they must not be given NULL arguments.
+var functions = map[string]interface{}{
+	"surface_gravity":         physics.SurfaceGravity,
+	"escape_velocity":         physics.EscapeVelocity,
+	"density":                 physics.Density,
+	"equilibrium_temperature": physics.EquilibriumTemperature,
+}
+
+func init() {
+	sql.Register(DriverName, &sqlite3.SQLiteDriver{ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+		for name, function := range functions {
+			if err := conn.RegisterFunc(name, function, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}})
+}
+
+// Open returns the sqlite dialector of the database at dsn, on the driver with the physics functions.
+func Open(dsn string) gorm.Dialector {
+	return sqlite.Dialector{DriverName: DriverName, DSN: dsn}
+}
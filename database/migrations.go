@@ -0,0 +1,43 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"gorm.io/gorm"
+)
+
+// Models lists every model migrated on startup.
+var Models = []interface{}{
+	&models.StarSystem{},
+	&models.Star{},
+	&models.PlanetTypeDefinition{},
+	&models.Planet{},
+	&models.Moon{},
+	&models.PlanetEvent{},
+	&models.Webhook{},
+	&models.WebhookDelivery{},
+}
+
+// CheckMigrations reports an error if a table or column of any migrated model is missing from the database.
+func CheckMigrations(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, model := range Models {
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(model); err != nil {
+			return err
+		}
+		if !migrator.HasTable(model) {
+			return fmt.Errorf("table %s is missing", statement.Schema.Table)
+		}
+		for _, field := range statement.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				return fmt.Errorf("column %s.%s is missing", statement.Schema.Table, field.DBName)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+)
+
+// Error is a GraphQL error exposing the problem code and HTTP status in its extensions.
+type Error struct {
+	Message string
+	Code    string
+	Status  int
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code, "status": e.Status}
+}
+
+// wrap runs a resolver, turning service errors into GraphQL errors with the same codes the REST API uses.
+// A missing planet resolves to null on nullable fields instead of failing the query.
+func wrap(p graphql.ResolveParams, resolve func() (interface{}, error)) (interface{}, error) {
+	result, err := resolve()
+	if err == nil {
+		return result, nil
+	}
+
+	var problem *problems.Problem
+	if !errors.As(err, &problem) {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		slog.ErrorContext(p.Context, "unhandled error", slog.String("error", err.Error()))
+		problem = problems.InternalError("An unexpected error occurred. Try again later.")
+	}
+	if problem.Code == problems.NotFound {
+		if _, nonNull := p.Info.ReturnType.(*graphql.NonNull); !nonNull {
+			return nil, nil
+		}
+	}
+	return nil, &Error{Message: problem.Detail, Code: problem.Code, Status: problem.Status}
+}
+
+// parseID reads a GraphQL ID argument as a positive planet id.
+func parseID(value interface{}) (int64, error) {
+	text, _ := value.(string)
+	id, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, problems.BadRequest(problems.InvalidID, "Could not parse planet id.")
+	}
+	return id, nil
+}
@@ -0,0 +1,112 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+type request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables" form:"-"`
+}
+
+// Handler serves GraphQL requests over GET and POST, mutations are only accepted over POST.
+func Handler(db *gorm.DB, limits Limits) gin.HandlerFunc {
+	schema, err := NewSchema(services.NewPlanets(db))
+	if err != nil {
+		panic(err)
+	}
+
+	// Handler for resolving a GraphQL request
+	return func(context *gin.Context) {
+		var body request
+		if err := bind(context, &body); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(body.Query), Name: "GraphQL request"})})
+		if err != nil {
+			context.JSON(http.StatusOK, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+		if validation := graphql.ValidateDocument(&schema, document, nil); !validation.IsValid {
+			context.JSON(http.StatusOK, &graphql.Result{Errors: validation.Errors})
+			return
+		}
+
+		if operation := findOperation(document, body.OperationName); operation != nil {
+			if operation.Operation != ast.OperationTypeQuery && context.Request.Method != http.MethodPost {
+				problems.Abort(context, problems.New(http.StatusMethodNotAllowed, problems.MethodNotAllowed, "Mutations must be sent with POST."))
+				return
+			}
+			if err := limits.check(document, operation, body.Variables); err != nil {
+				context.JSON(http.StatusOK, &graphql.Result{Errors: gqlerrors.FormatErrors(gqlerrors.NewLocatedError(err, nil))})
+				return
+			}
+		}
+
+		context.JSON(http.StatusOK, graphql.Execute(graphql.ExecuteParams{
+			Schema:        schema,
+			AST:           document,
+			OperationName: body.OperationName,
+			Args:          body.Variables,
+			Context:       context.Request.Context(),
+		}))
+	}
+}
+
+// bind reads the request from the query string on GET and from the JSON body otherwise.
+func bind(context *gin.Context, body *request) error {
+	if context.Request.Method == http.MethodGet {
+		if err := context.ShouldBindQuery(body); err != nil {
+			return problems.BadRequest(problems.InvalidQuery, "Could not parse the GraphQL request.")
+		}
+		if variables := context.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &body.Variables); err != nil {
+				return problems.BadRequest(problems.InvalidQuery, "Could not parse the GraphQL variables.")
+			}
+		}
+		if body.Query == "" {
+			return problems.BadRequest(problems.InvalidQuery, "The GraphQL request has no query.")
+		}
+		return nil
+	}
+
+	if err := context.ShouldBindJSON(body); err != nil || body.Query == "" {
+		return problems.BadRequest(problems.InvalidBody, "Could not parse the GraphQL request.")
+	}
+	return nil
+}
+
+// findOperation picks the operation to run, the only one when no name is given.
+// Unknown or ambiguous names are left for the executor to report.
+func findOperation(document *ast.Document, operationName string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" {
+			if found != nil {
+				return nil
+			}
+			found = operation
+		} else if operation.Name != nil && operation.Name.Value == operationName {
+			return operation
+		}
+	}
+	return found
+}
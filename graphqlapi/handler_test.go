@@ -0,0 +1,208 @@
+package graphqlapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func setupRouter(t *testing.T, limits Limits) *gin.Engine {
+	db, err := gorm.Open(database.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	db.Create(&models.PlanetTypeDefinition{Name: "hycean", MinRadius: 1, MaxRadius: 3, MinMass: 1, MaxMass: 10, GravityModel: models.MassGravity})
+	starId := uint(1)
+	db.Create(&models.Star{Name: "Kepler-22", SpectralType: "G", Mass: 0.97, Luminosity: 0.79})
+	db.Create(&[]models.Planet{
+		{Name: "Jupiter", Description: "A far away planet", Distance: 20, Radius: 9, Mass: 5, Type: models.GasGiant},
+		{Name: "Pluto", Description: "A small planet", Distance: 50, Radius: 2, Mass: 2, Type: models.Terrestrial},
+		{Name: "Kepler", Description: "A heavy planet", Distance: 600, Radius: 3, Mass: 9, Type: models.Terrestrial, StarID: &starId},
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/graphql", Handler(db, limits))
+	router.POST("/graphql", Handler(db, limits))
+	return router
+}
+
+func post(router *gin.Engine, query string, variables map[string]interface{}) (int, response) {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var result response
+	json.Unmarshal(w.Body.Bytes(), &result)
+	return w.Code, result
+}
+
+func TestQueries(t *testing.T) {
+	router := setupRouter(t, DefaultLimits)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			"filter sort and paginate",
+			`{ planets(filter: [{field: distance, gt: 30}, {field: type, eq: "terrestrial"}], sort: [{field: mass, direction: DESC}], limit: 1) { name type } }`,
+			`{"planets":[{"name":"Kepler","type":"terrestrial"}]}`,
+		},
+		{
+			"filter on the host star",
+			`{ planets(filter: [{field: star_spectral_type, eq: "G"}]) { name starId } }`,
+			`{"planets":[{"name":"Kepler","starId":"1"}]}`,
+		},
+		{
+			"filter and sort on derived properties",
+			`{ planets(filter: [{field: density, gt: 1}], sort: [{field: surface_gravity, direction: DESC}]) { name } }`,
+			`{"planets":[{"name":"Kepler"},{"name":"Pluto"}]}`,
+		},
+		{
+			"fuel cost for several crews",
+			`{ planet(id: "2") { name small: fuelCost(crew: 1) large: fuelCost(crew: 10) } }`,
+			`{"planet":{"large":2000,"name":"Pluto","small":200}}`,
+		},
+		{
+			"unknown planet resolves to null",
+			`{ planet(id: "42") { name } }`,
+			`{"planet":null}`,
+		},
+	}
+	for _, test := range tests {
+		status, result := post(router, test.query, nil)
+		assert.Equal(t, http.StatusOK, status, test.name)
+		assert.Empty(t, result.Errors, test.name)
+		data, _ := json.Marshal(result.Data)
+		assert.JSONEq(t, test.expected, string(data), test.name)
+	}
+}
+
+func TestMutations(t *testing.T) {
+	router := setupRouter(t, DefaultLimits)
+
+	_, result := post(router, `mutation($input: PlanetInput!) { createPlanet(input: $input) { id name mass } }`, map[string]interface{}{
+		"input": map[string]interface{}{"name": "Saturn", "description": "Ringed", "distance": 90, "radius": 8, "mass": 3, "type": "gas_giant"},
+	})
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, map[string]interface{}{"id": "4", "name": "Saturn", "mass": 3.0}, result.Data["createPlanet"])
+
+	_, result = post(router, `mutation { updatePlanet(id: "4", input: {name: "Saturn", description: "Ringed", distance: 95, radius: 8, mass: 3, type: "gas_giant"}) { distance } }`, nil)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, map[string]interface{}{"distance": 95.0}, result.Data["updatePlanet"])
+
+	_, result = post(router, `mutation { deletePlanet(id: "4") }`, nil)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "4", result.Data["deletePlanet"])
+
+	// types stored in the database are accepted as well
+	_, result = post(router, `mutation { createPlanet(input: {name: "K2-18b", description: "An ocean world", distance: 124, radius: 2.6, mass: 8.6, type: "hycean"}) { name type } }`, nil)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, map[string]interface{}{"name": "K2-18b", "type": "hycean"}, result.Data["createPlanet"])
+
+	errorTests := []struct {
+		name            string
+		query           string
+		expectedCode    string
+		expectedMessage string
+	}{
+		{"duplicate", `mutation { createPlanet(input: {name: "Pluto", description: "Again", distance: 50, radius: 2, mass: 2, type: "terrestrial"}) { id } }`, "conflict", "A planet named Pluto already exists."},
+		{"out of range", `mutation { createPlanet(input: {name: "Far", description: "Too far", distance: 4000, radius: 2, mass: 2, type: "terrestrial"}) { id } }`, "validation_failed", "Distance should be between 10 and 1000 light years."},
+		{"unknown type", `mutation { createPlanet(input: {name: "Far", description: "Unknown", distance: 40, radius: 2, mass: 2, type: "ice_world"}) { id } }`, "validation_failed", "Invalid planet type."},
+		{"missing planet", `mutation { deletePlanet(id: "4") }`, "not_found", "Could not find planet 4."},
+		{"invalid id", `mutation { deletePlanet(id: "abc") }`, "invalid_id", "Could not parse planet id."},
+		{"invalid crew", `{ planet(id: "1") { fuelCost(crew: 0) } }`, "validation_failed", "Crew capacity should be positive."},
+		{"non positive limit", `{ planets(limit: 0) { name } }`, "invalid_query", "Limit should be positive."},
+		{"limit too large", `{ planets(limit: 1001) { name } }`, "invalid_query", "Limit should be at most 1000."},
+	}
+	for _, test := range errorTests {
+		_, result := post(router, test.query, nil)
+		if assert.Len(t, result.Errors, 1, test.name) {
+			assert.Equal(t, test.expectedMessage, result.Errors[0].Message, test.name)
+			assert.Equal(t, test.expectedCode, result.Errors[0].Extensions["code"], test.name)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	router := setupRouter(t, Limits{MaxDepth: 2, MaxComplexity: 50})
+
+	tests := []struct {
+		name         string
+		query        string
+		variables    map[string]interface{}
+		expectedCode string
+	}{
+		{"within limits", `{ planets(limit: 10) { name mass } }`, nil, ""},
+		{"unpaginated list", `{ planets { name } }`, nil, QueryTooComplex},
+		{"limit from variable", `query($limit: Int) { planets(limit: $limit) { ...names } } fragment names on Planet { name type }`, map[string]interface{}{"limit": 30}, QueryTooComplex},
+		{"limit from a variable default", `query($limit: Int = 100000) { planets(limit: $limit) { name } }`, nil, QueryTooComplex},
+		{"small variable default", `query($limit: Int = 10) { planets(limit: $limit) { name mass } }`, nil, ""},
+		{"aliases add up", `{ planets(limit: 10) { a: fuelCost(crew: 1) b: fuelCost(crew: 2) c: fuelCost(crew: 3) d: fuelCost(crew: 4) e: fuelCost(crew: 5) } }`, nil, QueryTooComplex},
+		{"introspection is free", `{ __schema { types { name fields { name type { name ofType { name } } } } } }`, nil, ""},
+	}
+	for _, test := range tests {
+		_, result := post(router, test.query, test.variables)
+		if test.expectedCode == "" {
+			assert.Empty(t, result.Errors, test.name)
+			continue
+		}
+		if assert.Len(t, result.Errors, 1, test.name) {
+			assert.Equal(t, test.expectedCode, result.Errors[0].Extensions["code"], test.name)
+			assert.Nil(t, result.Data, test.name)
+		}
+	}
+}
+
+func TestGetRequests(t *testing.T) {
+	router := setupRouter(t, DefaultLimits)
+
+	tests := []struct {
+		name           string
+		query          url.Values
+		expectedStatus int
+	}{
+		{"query", url.Values{"query": {`query($id: ID!) { planet(id: $id) { name } }`}, "variables": {`{"id": "1"}`}}, http.StatusOK},
+		{"mutation", url.Values{"query": {`mutation { deletePlanet(id: "1") }`}}, http.StatusMethodNotAllowed},
+		{"missing query", url.Values{}, http.StatusBadRequest},
+		{"malformed variables", url.Values{"query": {`{ planets { name } }`}, "variables": {`{`}}, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest("GET", "/graphql?"+test.query.Encode(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, test.expectedStatus, w.Code, test.name)
+	}
+
+	req, _ := http.NewRequest("GET", "/graphql?"+url.Values{"query": {`{ planet(id: "1") { name } }`}}.Encode(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.JSONEq(t, `{"data":{"planet":{"name":"Jupiter"}}}`, w.Body.String())
+}
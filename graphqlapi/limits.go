@@ -0,0 +1,123 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Codes reported in the extensions of rejected queries.
+const (
+	QueryTooDeep    = "query_too_deep"
+	QueryTooComplex = "query_too_complex"
+)
+
+// Limits bounds the queries accepted by the endpoint, checked before anything is resolved.
+// Every field costs 1, fields below a list cost once per requested item.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// DefaultLimits allow listing a full page of planets with several fuel cost aliases.
+var DefaultLimits = Limits{MaxDepth: 5, MaxComplexity: 2000}
+
+// defaultListLimit is the limit of lists fetched without one, so that their cost is known before resolving.
+const defaultListLimit = 100
+
+// maxListLimit bounds the limit of lists, whatever their estimated cost.
+const maxListLimit = 1000
+
+// listFields are the fields returning lists, sized by their limit argument.
+var listFields = map[string]bool{"planets": true}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// check rejects operations nested deeper or more expensive than the limits allow.
+func (limits Limits) check(document *ast.Document, operation *ast.OperationDefinition, variables map[string]interface{}) error {
+	a := analyzer{fragments: map[string]*ast.FragmentDefinition{}, variables: map[string]interface{}{}}
+	// variables left out take the default value of their definition
+	for _, definition := range operation.VariableDefinitions {
+		if value, ok := definition.DefaultValue.(*ast.IntValue); ok {
+			if limit, err := strconv.Atoi(value.Value); err == nil {
+				a.variables[definition.Variable.Name.Value] = float64(limit)
+			}
+		}
+	}
+	for name, value := range variables {
+		a.variables[name] = value
+	}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	depth, complexity := a.measure(operation.SelectionSet, 0)
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return &Error{Message: fmt.Sprintf("Query depth %d exceeds the limit of %d.", depth, limits.MaxDepth), Code: QueryTooDeep, Status: http.StatusBadRequest}
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return &Error{Message: fmt.Sprintf("Query complexity %d exceeds the limit of %d.", complexity, limits.MaxComplexity), Code: QueryTooComplex, Status: http.StatusBadRequest}
+	}
+	return nil
+}
+
+// measure returns the deepest field nesting and the cost of a selection set, introspection fields are free.
+// Validation has already rejected fragment cycles, so following spreads terminates.
+func (a analyzer) measure(selectionSet *ast.SelectionSet, depth int) (int, int) {
+	maxDepth, complexity := depth, 0
+	if selectionSet == nil {
+		return maxDepth, complexity
+	}
+	for _, selection := range selectionSet.Selections {
+		var selectionDepth, selectionComplexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			var childComplexity int
+			selectionDepth, childComplexity = a.measure(selection.SelectionSet, depth+1)
+			selectionComplexity = 1 + a.size(selection)*childComplexity
+		case *ast.InlineFragment:
+			selectionDepth, selectionComplexity = a.measure(selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				selectionDepth, selectionComplexity = a.measure(fragment.SelectionSet, depth)
+			}
+		}
+		maxDepth = max(maxDepth, selectionDepth)
+		complexity += selectionComplexity
+	}
+	return maxDepth, complexity
+}
+
+// size is the number of items a field resolves to, read from the limit argument of list fields.
+func (a analyzer) size(field *ast.Field) int {
+	if !listFields[field.Name.Value] {
+		return 1
+	}
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if limit, err := strconv.Atoi(value.Value); err == nil && limit > 0 {
+				return limit
+			}
+		case *ast.Variable:
+			if limit, ok := a.variables[value.Name.Value].(float64); ok && limit > 0 {
+				return int(limit)
+			}
+		}
+	}
+	return defaultListLimit
+}
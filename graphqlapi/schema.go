@@ -0,0 +1,375 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+)
+
+// valueScalar carries filter operands, which are strings or numbers depending on the filtered field.
+var valueScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Value",
+	Description: "A string, number or boolean compared against a planet field.",
+	Serialize:   func(value interface{}) interface{} { return value },
+	ParseValue:  func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			if value, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+				return value
+			}
+		case *ast.FloatValue:
+			if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+				return value
+			}
+		case *ast.StringValue:
+			return valueAST.Value
+		case *ast.BooleanValue:
+			return valueAST.Value
+		}
+		return nil
+	},
+})
+
+// planetFieldEnum lists the filterable and sortable planet fields, so unknown fields fail validation.
+// Fields of the host star ("star.mass") are named star_mass, as enum names cannot contain dots.
+var planetFieldEnum = func() *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	for field := range models.DerivedPlanetFilters {
+		values[strings.ReplaceAll(field, ".", "_")] = &graphql.EnumValueConfig{Value: field}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{Name: "PlanetField", Values: values})
+}()
+
+var sortDirectionEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "SortDirection",
+	Values: graphql.EnumValueConfigMap{
+		"ASC":  &graphql.EnumValueConfig{Value: "asc"},
+		"DESC": &graphql.EnumValueConfig{Value: "desc"},
+	},
+})
+
+var planetFilterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "PlanetFilter",
+	Description: "Conditions on one planet field, all given conditions must hold.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(planetFieldEnum)},
+		"eq":    &graphql.InputObjectFieldConfig{Type: valueScalar},
+		"neq":   &graphql.InputObjectFieldConfig{Type: valueScalar},
+		"gt":    &graphql.InputObjectFieldConfig{Type: valueScalar},
+		"gte":   &graphql.InputObjectFieldConfig{Type: valueScalar},
+		"lt":    &graphql.InputObjectFieldConfig{Type: valueScalar},
+		"lte":   &graphql.InputObjectFieldConfig{Type: valueScalar},
+		"like":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"in":    &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		"notIn": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	},
+})
+
+var planetSortInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "PlanetSort",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(planetFieldEnum)},
+		"direction": &graphql.InputObjectFieldConfig{Type: sortDirectionEnum, DefaultValue: "asc"},
+	},
+})
+
+var planetInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "PlanetInput",
+	Description: "Distance must be within (10, 1000) light years, radius and mass within the ranges of the planet type, in Earth radii and Earth masses. Gas giants keep their mass, although their fuel cost does not use it.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":          &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"description":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"distance":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"radius":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+		"mass":          &graphql.InputObjectFieldConfig{Type: graphql.Float},
+		"type":          &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: "Name of a built-in or stored planet type, such as gas_giant, as listed by /planet-types."},
+		"starId":        &graphql.InputObjectFieldConfig{Type: graphql.ID},
+		"distanceError": &graphql.InputObjectFieldConfig{Type: graphql.Float, Description: "Standard deviation of the distance."},
+		"radiusError":   &graphql.InputObjectFieldConfig{Type: graphql.Float, Description: "Standard deviation of the radius."},
+		"massError":     &graphql.InputObjectFieldConfig{Type: graphql.Float, Description: "Standard deviation of the mass."},
+		"semiMajorAxis": &graphql.InputObjectFieldConfig{Type: graphql.Float, Description: "Radius of the orbit around the host star, in astronomical units."},
+	},
+})
+
+// NewSchema builds the GraphQL schema, resolving every field through the shared planet service.
+func NewSchema(planets *services.Planets) (graphql.Schema, error) {
+	planetType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Planet",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return strconv.FormatUint(uint64(p.Source.(models.Planet).ID), 10), nil
+			}},
+			"name":        planetField(graphql.NewNonNull(graphql.String), func(planet models.Planet) interface{} { return planet.Name }),
+			"description": planetField(graphql.NewNonNull(graphql.String), func(planet models.Planet) interface{} { return planet.Description }),
+			"distance":    planetField(graphql.NewNonNull(graphql.Int), func(planet models.Planet) interface{} { return planet.Distance }),
+			"radius":      planetField(graphql.NewNonNull(graphql.Float), func(planet models.Planet) interface{} { return planet.Radius }),
+			"mass":        planetField(graphql.NewNonNull(graphql.Float), func(planet models.Planet) interface{} { return planet.Mass }),
+			"type":        planetField(graphql.NewNonNull(graphql.String), func(planet models.Planet) interface{} { return string(planet.Type) }),
+			"starId": &graphql.Field{Type: graphql.ID, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if starId := p.Source.(models.Planet).StarID; starId != nil {
+					return strconv.FormatUint(uint64(*starId), 10), nil
+				}
+				return nil, nil
+			}},
+			"distanceError": planetField(graphql.Float, func(planet models.Planet) interface{} { return optional(planet.DistanceError) }),
+			"radiusError":   planetField(graphql.Float, func(planet models.Planet) interface{} { return optional(planet.RadiusError) }),
+			"massError":     planetField(graphql.Float, func(planet models.Planet) interface{} { return optional(planet.MassError) }),
+			"semiMajorAxis": planetField(graphql.Float, func(planet models.Planet) interface{} { return optional(planet.SemiMajorAxis) }),
+			"createdAt":     planetField(graphql.NewNonNull(graphql.DateTime), func(planet models.Planet) interface{} { return planet.CreatedAt }),
+			"updatedAt":     planetField(graphql.NewNonNull(graphql.DateTime), func(planet models.Planet) interface{} { return planet.UpdatedAt }),
+			"fuelCost": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "Estimated fuel needed to take a crew of the given size to the planet.",
+				Args:        graphql.FieldConfigArgument{"crew": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return wrap(p, func() (interface{}, error) {
+						return planets.Quote(p.Source.(models.Planet), int64(p.Args["crew"].(int)))
+					})
+				},
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"planets": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(planetType))),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(planetFilterInput))},
+					"sort":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(planetSortInput))},
+					"page":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return wrap(p, func() (interface{}, error) {
+						params, err := toQueryParams(p.Args)
+						if err != nil {
+							return nil, err
+						}
+						return planets.List(p.Context, params)
+					})
+				},
+			},
+			"planet": &graphql.Field{
+				Type: planetType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return wrap(p, func() (interface{}, error) {
+						planetId, err := parseID(p.Args["id"])
+						if err != nil {
+							return nil, err
+						}
+						return planets.Get(p.Context, planetId)
+					})
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPlanet": &graphql.Field{
+				Type: graphql.NewNonNull(planetType),
+				Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(planetInput)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return wrap(p, func() (interface{}, error) {
+						planet, err := fromInput(p.Args["input"])
+						if err != nil {
+							return nil, err
+						}
+						if err := planets.Create(p.Context, &planet); err != nil {
+							return nil, err
+						}
+						return planet, nil
+					})
+				},
+			},
+			"updatePlanet": &graphql.Field{
+				Type: graphql.NewNonNull(planetType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(planetInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return wrap(p, func() (interface{}, error) {
+						planetId, err := parseID(p.Args["id"])
+						if err != nil {
+							return nil, err
+						}
+						updatedPlanet, err := fromInput(p.Args["input"])
+						if err != nil {
+							return nil, err
+						}
+						return planets.Replace(p.Context, planetId, updatedPlanet)
+					})
+				},
+			},
+			"deletePlanet": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return wrap(p, func() (interface{}, error) {
+						planetId, err := parseID(p.Args["id"])
+						if err != nil {
+							return nil, err
+						}
+						if err := planets.Delete(p.Context, planetId); err != nil {
+							return nil, err
+						}
+						return p.Args["id"], nil
+					})
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func planetField(fieldType graphql.Output, get func(models.Planet) interface{}) *graphql.Field {
+	return &graphql.Field{Type: fieldType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(models.Planet)), nil
+	}}
+}
+
+// toQueryParams maps the planets arguments onto the query operations used by the REST API.
+// Lists are always paginated, defaultListLimit planets at a time unless a positive limit up to maxListLimit is given.
+func toQueryParams(args map[string]interface{}) (*queryoperations.QueryParams, error) {
+	params := &queryoperations.QueryParams{Filters: map[string]queryoperations.FilterParam{}, Page: 1, Limit: defaultListLimit}
+	if page, ok := args["page"].(int); ok {
+		if page <= 0 {
+			return nil, problems.BadRequest(problems.InvalidQuery, "Page should be positive.")
+		}
+		params.Page = page
+	}
+	if limit, ok := args["limit"].(int); ok {
+		if limit <= 0 {
+			return nil, problems.BadRequest(problems.InvalidQuery, "Limit should be positive.")
+		}
+		if limit > maxListLimit {
+			return nil, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Limit should be at most %d.", maxListLimit))
+		}
+		params.Limit = limit
+	}
+
+	filters, _ := args["filter"].([]interface{})
+	for _, filter := range filters {
+		filter := filter.(map[string]interface{})
+		field := filter["field"].(string)
+		params.Filters[field] = mergeFilters(params.Filters[field], queryoperations.FilterParam{
+			Eq:    filter["eq"],
+			Neq:   filter["neq"],
+			Gt:    filter["gt"],
+			Gte:   filter["gte"],
+			Lt:    filter["lt"],
+			Lte:   filter["lte"],
+			Like:  stringValue(filter["like"]),
+			In:    stringList(filter["in"]),
+			NotIn: stringList(filter["notIn"]),
+		})
+	}
+
+	sorts, _ := args["sort"].([]interface{})
+	terms := make([]string, 0, len(sorts))
+	for _, term := range sorts {
+		term := term.(map[string]interface{})
+		terms = append(terms, fmt.Sprintf("%s %s", term["field"], term["direction"]))
+	}
+	params.Sort = strings.Join(terms, ", ")
+	return params, nil
+}
+
+// mergeFilters combines two filters on the same field, conditions of next win when both set one.
+func mergeFilters(current queryoperations.FilterParam, next queryoperations.FilterParam) queryoperations.FilterParam {
+	if next.Eq != nil {
+		current.Eq = next.Eq
+	}
+	if next.Neq != nil {
+		current.Neq = next.Neq
+	}
+	if next.Gt != nil {
+		current.Gt = next.Gt
+	}
+	if next.Gte != nil {
+		current.Gte = next.Gte
+	}
+	if next.Lt != nil {
+		current.Lt = next.Lt
+	}
+	if next.Lte != nil {
+		current.Lte = next.Lte
+	}
+	if next.Like != "" {
+		current.Like = next.Like
+	}
+	if next.In != nil {
+		current.In = next.In
+	}
+	if next.NotIn != nil {
+		current.NotIn = next.NotIn
+	}
+	return current
+}
+
+func fromInput(input interface{}) (models.Planet, error) {
+	fields := input.(map[string]interface{})
+	planet := models.Planet{
+		Name:        fields["name"].(string),
+		Description: fields["description"].(string),
+		Distance:    int64(fields["distance"].(int)),
+		Radius:      fields["radius"].(float64),
+		Type:        models.PlanetType(fields["type"].(string)),
+	}
+	if mass, ok := fields["mass"].(float64); ok {
+		planet.Mass = mass
+	}
+	for name, target := range map[string]**float64{"distanceError": &planet.DistanceError, "radiusError": &planet.RadiusError, "massError": &planet.MassError, "semiMajorAxis": &planet.SemiMajorAxis} {
+		if value, ok := fields[name].(float64); ok {
+			*target = &value
+		}
+	}
+	if fields["starId"] != nil {
+		starId, err := strconv.ParseUint(stringValue(fields["starId"]), 10, 64)
+		if err != nil || starId == 0 {
+			return planet, problems.BadRequest(problems.InvalidID, "Could not parse star id.")
+		}
+		star := uint(starId)
+		planet.StarID = &star
+	}
+	return planet, nil
+}
+
+// optional resolves a missing measurement error to null.
+func optional(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func stringValue(value interface{}) string {
+	text, _ := value.(string)
+	return text
+}
+
+func stringList(value interface{}) []string {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, value.(string))
+	}
+	return texts
+}
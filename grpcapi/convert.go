@@ -0,0 +1,68 @@
+package grpcapi
+
+import (
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	voyagersv1 "github.com/kaitou-1412/Go-Space-Voyagers/proto/voyagers/v1"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProto(planet models.Planet) *voyagersv1.Planet {
+	return &voyagersv1.Planet{
+		Id:          uint64(planet.ID),
+		Name:        planet.Name,
+		Description: planet.Description,
+		Distance:    planet.Distance,
+		Radius:      planet.Radius,
+		Mass:        planet.Mass,
+		Type:        string(planet.Type),
+		CreatedAt:   timestamppb.New(planet.CreatedAt),
+		UpdatedAt:   timestamppb.New(planet.UpdatedAt),
+	}
+}
+
+func fromProto(planet *voyagersv1.Planet) models.Planet {
+	if planet == nil {
+		return models.Planet{}
+	}
+	return models.Planet{
+		Name:        planet.GetName(),
+		Description: planet.GetDescription(),
+		Distance:    planet.GetDistance(),
+		Radius:      planet.GetRadius(),
+		Mass:        planet.GetMass(),
+		Type:        models.PlanetType(planet.GetType()),
+	}
+}
+
+func toQueryParams(sort string, filters map[string]*voyagersv1.FilterParam, page int32, limit int32) *queryoperations.QueryParams {
+	params := &queryoperations.QueryParams{
+		Sort:    sort,
+		Page:    int(page),
+		Limit:   int(limit),
+		Filters: make(map[string]queryoperations.FilterParam, len(filters)),
+	}
+	for field, filter := range filters {
+		params.Filters[field] = queryoperations.FilterParam{
+			Eq:    value(filter.GetEq()),
+			Neq:   value(filter.GetNeq()),
+			Gt:    value(filter.GetGt()),
+			Gte:   value(filter.GetGte()),
+			Lt:    value(filter.GetLt()),
+			Lte:   value(filter.GetLte()),
+			Like:  filter.GetLike(),
+			In:    filter.GetIn(),
+			NotIn: filter.GetNotIn(),
+		}
+	}
+	return params
+}
+
+// value unwraps a protobuf Value, an unset condition stays nil so it is not applied.
+func value(v *structpb.Value) interface{} {
+	if v == nil {
+		return nil
+	}
+	return v.AsInterface()
+}
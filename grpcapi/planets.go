@@ -0,0 +1,109 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	voyagersv1 "github.com/kaitou-1412/Go-Space-Voyagers/proto/voyagers/v1"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PlanetServer implements voyagersv1.PlanetServiceServer on top of the shared planet service.
+type PlanetServer struct {
+	voyagersv1.UnimplementedPlanetServiceServer
+	planets *services.Planets
+}
+
+func (s *PlanetServer) ListPlanets(ctx context.Context, req *voyagersv1.ListPlanetsRequest) (*voyagersv1.ListPlanetsResponse, error) {
+	params := toQueryParams(req.GetSort(), req.GetFilters(), req.GetPage(), req.GetLimit())
+	planets, err := s.planets.List(ctx, params)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	total, err := s.planets.Count(ctx, params)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &voyagersv1.ListPlanetsResponse{Total: total, Page: req.GetPage(), Limit: req.GetLimit()}
+	for _, planet := range planets {
+		response.Planets = append(response.Planets, toProto(planet))
+	}
+	return response, nil
+}
+
+func (s *PlanetServer) StreamPlanets(req *voyagersv1.StreamPlanetsRequest, stream voyagersv1.PlanetService_StreamPlanetsServer) error {
+	ctx := stream.Context()
+	params := toQueryParams(req.GetSort(), req.GetFilters(), 0, 0)
+	err := s.planets.Each(ctx, params, func(planet models.Planet) error {
+		return stream.Send(&voyagersv1.StreamPlanetsResponse{Planet: toProto(planet)})
+	})
+	return toStatus(ctx, err)
+}
+
+func (s *PlanetServer) GetPlanet(ctx context.Context, req *voyagersv1.GetPlanetRequest) (*voyagersv1.GetPlanetResponse, error) {
+	id, err := planetID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	planet, err := s.planets.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &voyagersv1.GetPlanetResponse{Planet: toProto(planet)}, nil
+}
+
+func (s *PlanetServer) CreatePlanet(ctx context.Context, req *voyagersv1.CreatePlanetRequest) (*voyagersv1.CreatePlanetResponse, error) {
+	planet := fromProto(req.GetPlanet())
+	if err := s.planets.Create(ctx, &planet); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &voyagersv1.CreatePlanetResponse{Planet: toProto(planet)}, nil
+}
+
+func (s *PlanetServer) UpdatePlanet(ctx context.Context, req *voyagersv1.UpdatePlanetRequest) (*voyagersv1.UpdatePlanetResponse, error) {
+	id, err := planetID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.planets.Update(ctx, id, fromProto(req.GetPlanet())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	planet, err := s.planets.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &voyagersv1.UpdatePlanetResponse{Planet: toProto(planet)}, nil
+}
+
+func (s *PlanetServer) DeletePlanet(ctx context.Context, req *voyagersv1.DeletePlanetRequest) (*voyagersv1.DeletePlanetResponse, error) {
+	id, err := planetID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.planets.Delete(ctx, id); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &voyagersv1.DeletePlanetResponse{}, nil
+}
+
+func (s *PlanetServer) GetFuelCost(ctx context.Context, req *voyagersv1.GetFuelCostRequest) (*voyagersv1.GetFuelCostResponse, error) {
+	id, err := planetID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	fuelCost, err := s.planets.FuelCost(ctx, id, req.GetCrewCapacity())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &voyagersv1.GetFuelCostResponse{FuelCost: fuelCost}, nil
+}
+
+func planetID(id uint64) (int64, error) {
+	if id == 0 || id > 1<<63-1 {
+		return 0, status.Error(codes.InvalidArgument, "Could not parse planet id.")
+	}
+	return int64(id), nil
+}
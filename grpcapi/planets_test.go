@@ -0,0 +1,180 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	voyagersv1 "github.com/kaitou-1412/Go-Space-Voyagers/proto/voyagers/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+)
+
+func setupClient(t *testing.T) (voyagersv1.PlanetServiceClient, *gorm.DB) {
+	db, err := gorm.Open(database.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(db)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		sqlDB.Close()
+	})
+	return voyagersv1.NewPlanetServiceClient(conn), db
+}
+
+func TestPlanetService(t *testing.T) {
+	client, _ := setupClient(t)
+	ctx := context.Background()
+
+	seeds := []*voyagersv1.Planet{
+		{Name: "Jupiter", Description: "A far away planet", Distance: 20, Radius: 9, Mass: 8, Type: "gas_giant"},
+		{Name: "Pluto", Description: "A small planet", Distance: 50, Radius: 2, Mass: 2, Type: "terrestrial"},
+		{Name: "Kepler", Description: "A heavy planet", Distance: 600, Radius: 3, Mass: 9, Type: "terrestrial"},
+	}
+	for _, seed := range seeds {
+		created, err := client.CreatePlanet(ctx, &voyagersv1.CreatePlanetRequest{Planet: seed})
+		if err != nil {
+			t.Fatalf("Failed to create planet: %v", err)
+		}
+		assert.NotZero(t, created.GetPlanet().GetId())
+	}
+
+	tests := []struct {
+		name            string
+		call            func() error
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{"duplicate name", func() error {
+			_, err := client.CreatePlanet(ctx, &voyagersv1.CreatePlanetRequest{Planet: seeds[1]})
+			return err
+		}, codes.AlreadyExists, "A planet named Pluto already exists."},
+		{"out of range", func() error {
+			_, err := client.CreatePlanet(ctx, &voyagersv1.CreatePlanetRequest{Planet: &voyagersv1.Planet{Name: "Far", Description: "Too far", Distance: 4000, Radius: 2, Mass: 2, Type: "terrestrial"}})
+			return err
+		}, codes.InvalidArgument, "Distance should be between 10 and 1000 light years."},
+		{"missing fields", func() error {
+			_, err := client.CreatePlanet(ctx, &voyagersv1.CreatePlanetRequest{})
+			return err
+		}, codes.InvalidArgument, "Could not parse request data."},
+		{"unknown planet", func() error {
+			_, err := client.GetPlanet(ctx, &voyagersv1.GetPlanetRequest{Id: 42})
+			return err
+		}, codes.NotFound, "Could not find planet 42."},
+		{"invalid id", func() error {
+			_, err := client.DeletePlanet(ctx, &voyagersv1.DeletePlanetRequest{})
+			return err
+		}, codes.InvalidArgument, "Could not parse planet id."},
+		{"invalid sort", func() error {
+			_, err := client.ListPlanets(ctx, &voyagersv1.ListPlanetsRequest{Sort: "mass; DROP TABLE planets"})
+			return err
+		}, codes.InvalidArgument, `invalid sort expression "mass; DROP TABLE planets"`},
+		{"invalid crew", func() error {
+			_, err := client.GetFuelCost(ctx, &voyagersv1.GetFuelCostRequest{Id: 2})
+			return err
+		}, codes.InvalidArgument, "Crew capacity should be positive."},
+	}
+	for _, test := range tests {
+		err := test.call()
+		assert.Equal(t, test.expectedCode, status.Code(err), test.name)
+		assert.Equal(t, test.expectedMessage, status.Convert(err).Message(), test.name)
+	}
+
+	list, err := client.ListPlanets(ctx, &voyagersv1.ListPlanetsRequest{
+		Sort:    "mass desc",
+		Filters: map[string]*voyagersv1.FilterParam{"distance": {Gt: structpb.NewNumberValue(30)}},
+	})
+	if assert.NoError(t, err) && assert.Len(t, list.GetPlanets(), 2) {
+		assert.Equal(t, "Kepler", list.GetPlanets()[0].GetName())
+		assert.EqualValues(t, 2, list.GetTotal())
+	}
+
+	// the total counts the matching planets of all pages
+	page, err := client.ListPlanets(ctx, &voyagersv1.ListPlanetsRequest{Sort: "name", Page: 2, Limit: 2})
+	if assert.NoError(t, err) && assert.Len(t, page.GetPlanets(), 1) {
+		assert.Equal(t, "Pluto", page.GetPlanets()[0].GetName())
+		assert.EqualValues(t, 3, page.GetTotal())
+	}
+
+	fuel, err := client.GetFuelCost(ctx, &voyagersv1.GetFuelCostRequest{Id: 2, CrewCapacity: 10})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2000.0, fuel.GetFuelCost())
+	}
+
+	updated, err := client.UpdatePlanet(ctx, &voyagersv1.UpdatePlanetRequest{Id: 2, Planet: &voyagersv1.Planet{Name: "Charon", Description: "Renamed", Distance: 60, Radius: 2, Mass: 2, Type: "terrestrial"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Charon", updated.GetPlanet().GetName())
+		assert.EqualValues(t, 60, updated.GetPlanet().GetDistance())
+	}
+
+	_, err = client.DeletePlanet(ctx, &voyagersv1.DeletePlanetRequest{Id: 3})
+	assert.NoError(t, err)
+
+	stream, err := client.StreamPlanets(ctx, &voyagersv1.StreamPlanetsRequest{Sort: "name"})
+	if err != nil {
+		t.Fatalf("Failed to stream planets: %v", err)
+	}
+	var names []string
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		names = append(names, message.GetPlanet().GetName())
+	}
+	assert.Equal(t, []string{"Charon", "Jupiter"}, names)
+}
+
+func TestUpdatePlanetKeepsStarAndErrors(t *testing.T) {
+	client, db := setupClient(t)
+	ctx := context.Background()
+
+	starId, radiusError, semiMajorAxis := uint(1), 0.2, 1.5
+	db.Create(&models.Star{Name: "Kepler-22", SpectralType: "G", Mass: 0.97, Luminosity: 0.79})
+	db.Create(&models.Planet{Name: "Kepler", Description: "A heavy planet", Distance: 600, Radius: 3, Mass: 9, Type: models.Terrestrial,
+		StarID: &starId, RadiusError: &radiusError, SemiMajorAxis: &semiMajorAxis})
+
+	// the proto has no fields for them, so an update keeps the host star and the optional measurements
+	_, err := client.UpdatePlanet(ctx, &voyagersv1.UpdatePlanetRequest{Id: 1, Planet: &voyagersv1.Planet{Name: "Kepler", Description: "Renamed", Distance: 610, Radius: 3, Mass: 9, Type: "terrestrial"}})
+	assert.NoError(t, err)
+
+	var planet models.Planet
+	db.First(&planet, 1)
+	assert.Equal(t, "Renamed", planet.Description)
+	if assert.NotNil(t, planet.StarID) && assert.NotNil(t, planet.RadiusError) && assert.NotNil(t, planet.SemiMajorAxis) {
+		assert.EqualValues(t, 1, *planet.StarID)
+		assert.Equal(t, 0.2, *planet.RadiusError)
+		assert.Equal(t, 1.5, *planet.SemiMajorAxis)
+	}
+	assert.Nil(t, planet.MassError)
+}
@@ -0,0 +1,82 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	voyagersv1 "github.com/kaitou-1412/Go-Space-Voyagers/proto/voyagers/v1"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// NewServer returns a gRPC server exposing the planet service, the standard health service and reflection.
+func NewServer(db *gorm.DB, options ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(recoverUnary), grpc.ChainStreamInterceptor(recoverStream)}, options...)...)
+	voyagersv1.RegisterPlanetServiceServer(server, &PlanetServer{planets: services.NewPlanets(db)})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(voyagersv1.PlanetService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+	return server
+}
+
+// toStatus maps service errors onto gRPC status codes, the same way the REST API maps them onto HTTP statuses.
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	var problem *problems.Problem
+	if !errors.As(err, &problem) {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return status.FromContextError(err).Err()
+		}
+		slog.ErrorContext(ctx, "unhandled error", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, "An unexpected error occurred. Try again later.")
+	}
+
+	code := codes.Internal
+	switch problem.Status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	return status.Error(code, problem.Detail)
+}
+
+// recoverUnary converts panics in unary handlers into internal errors instead of crashing the process.
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.ErrorContext(ctx, "panic recovered", slog.Any("panic", recovered), slog.String("method", info.FullMethod))
+			err = status.Error(codes.Internal, "An unexpected error occurred. Try again later.")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoverStream converts panics in streaming handlers into internal errors instead of crashing the process.
+func recoverStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.ErrorContext(stream.Context(), "panic recovered", slog.Any("panic", recovered), slog.String("method", info.FullMethod))
+			err = status.Error(codes.Internal, "An unexpected error occurred. Try again later.")
+		}
+	}()
+	return handler(srv, stream)
+}
@@ -0,0 +1,44 @@
+package initialize
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetEnv returns the value of the environment variable key, or fallback when it is unset.
+func GetEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// GetEnvDuration parses the environment variable key as a duration (e.g. "15s"), or returns fallback when it is unset.
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Error("Invalid duration in environment.", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return duration
+}
+
+// GetEnvInt parses the environment variable key as an integer, or returns fallback when it is unset.
+func GetEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Error("Invalid integer in environment.", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return number
+}
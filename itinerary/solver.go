@@ -0,0 +1,170 @@
+package itinerary
+
+import "math"
+
+// ExactLimit is the largest number of stops solved exactly, larger itineraries are planned with a heuristic.
+// Held-Karp needs 2^n * n^2 steps, 12 stops take a few million.
+const ExactLimit = 12
+
+// Plan is a visiting order of the stops and its total cost.
+type Plan struct {
+	// Order lists the stops 1..n in visiting order, the origin 0 is left out.
+	Order []int
+	Cost  float64
+	// Exact tells whether the plan is known to be the cheapest.
+	Exact bool
+}
+
+// Solve finds the cheapest order to visit every stop 1..n of costs once, starting from the origin 0 without
+// returning to it. costs[i][j] is the cost of travelling from i to j and need not be symmetric.
+// sequence lists stops that must be visited in that relative order, other stops may come before, between or after them.
+func Solve(costs [][]float64, sequence []int) Plan {
+	stops := len(costs) - 1
+	if stops <= 0 {
+		return Plan{Order: []int{}, Exact: true}
+	}
+
+	// predecessor[stop] is the stop that must be visited before it, 0 when there is none
+	predecessor := make([]int, stops+1)
+	for i := 1; i < len(sequence); i++ {
+		predecessor[sequence[i]] = sequence[i-1]
+	}
+
+	if stops <= ExactLimit {
+		return heldKarp(costs, predecessor)
+	}
+	return twoOpt(costs, predecessor, nearestNeighbour(costs, predecessor))
+}
+
+// Cost returns the cost of visiting the stops in order from the origin.
+func Cost(costs [][]float64, order []int) float64 {
+	total, previous := 0.0, 0
+	for _, stop := range order {
+		total += costs[previous][stop]
+		previous = stop
+	}
+	return total
+}
+
+// heldKarp solves the path exactly by dynamic programming over the sets of visited stops.
+func heldKarp(costs [][]float64, predecessor []int) Plan {
+	stops := len(costs) - 1
+	full := 1<<stops - 1
+
+	// best[visited][last] is the cheapest cost of visiting the set ending at last, parent the stop before last
+	best := make([][]float64, full+1)
+	parent := make([][]int, full+1)
+	for visited := range best {
+		best[visited] = make([]float64, stops)
+		parent[visited] = make([]int, stops)
+		for last := range best[visited] {
+			best[visited][last] = math.Inf(1)
+		}
+	}
+	for first := 0; first < stops; first++ {
+		if predecessor[first+1] == 0 {
+			best[1<<first][first] = costs[0][first+1]
+			parent[1<<first][first] = -1
+		}
+	}
+
+	for visited := 1; visited <= full; visited++ {
+		for last := 0; last < stops; last++ {
+			cost := best[visited][last]
+			if math.IsInf(cost, 1) {
+				continue
+			}
+			for next := 0; next < stops; next++ {
+				if visited&(1<<next) != 0 {
+					continue
+				}
+				if required := predecessor[next+1]; required != 0 && visited&(1<<(required-1)) == 0 {
+					continue
+				}
+				extended := visited | 1<<next
+				if candidate := cost + costs[last+1][next+1]; candidate < best[extended][next] {
+					best[extended][next] = candidate
+					parent[extended][next] = last
+				}
+			}
+		}
+	}
+
+	last := 0
+	for candidate := 1; candidate < stops; candidate++ {
+		if best[full][candidate] < best[full][last] {
+			last = candidate
+		}
+	}
+
+	plan := Plan{Order: make([]int, stops), Cost: best[full][last], Exact: true}
+	for visited, position := full, stops-1; last >= 0; position-- {
+		plan.Order[position] = last + 1
+		visited, last = visited&^(1<<last), parent[visited][last]
+	}
+	return plan
+}
+
+// nearestNeighbour builds a first order by always travelling to the cheapest stop allowed next.
+func nearestNeighbour(costs [][]float64, predecessor []int) []int {
+	stops := len(costs) - 1
+	visited := make([]bool, stops+1)
+	visited[0] = true
+	order := make([]int, 0, stops)
+
+	for current := 0; len(order) < stops; {
+		next := -1
+		for candidate := 1; candidate <= stops; candidate++ {
+			if visited[candidate] || !visited[predecessor[candidate]] {
+				continue
+			}
+			if next == -1 || costs[current][candidate] < costs[current][next] {
+				next = candidate
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		current = next
+	}
+	return order
+}
+
+// twoOpt improves order by reversing segments while that lowers the cost and keeps the required sequence.
+func twoOpt(costs [][]float64, predecessor []int, order []int) Plan {
+	cost := Cost(costs, order)
+	candidate := make([]int, len(order))
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(order)-1; i++ {
+			for k := i + 1; k < len(order); k++ {
+				copy(candidate, order)
+				for left, right := i, k; left < right; left, right = left+1, right-1 {
+					candidate[left], candidate[right] = candidate[right], candidate[left]
+				}
+				if !follows(candidate, predecessor) {
+					continue
+				}
+				// costs can be asymmetric, so the reversed segment is priced in full
+				if candidateCost := Cost(costs, candidate); candidateCost < cost-1e-9 {
+					order, candidate = candidate, order
+					cost = candidateCost
+					improved = true
+				}
+			}
+		}
+	}
+	return Plan{Order: order, Cost: cost}
+}
+
+// follows reports whether every stop of order comes after its required predecessor.
+func follows(order []int, predecessor []int) bool {
+	visited := make([]bool, len(predecessor))
+	visited[0] = true
+	for _, stop := range order {
+		if !visited[predecessor[stop]] {
+			return false
+		}
+		visited[stop] = true
+	}
+	return true
+}
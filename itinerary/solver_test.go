@@ -0,0 +1,117 @@
+package itinerary
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomCosts(random *rand.Rand, stops int) [][]float64 {
+	costs := make([][]float64, stops+1)
+	for i := range costs {
+		costs[i] = make([]float64, stops+1)
+		for j := range costs[i] {
+			if i != j {
+				costs[i][j] = float64(random.Intn(1000))
+			}
+		}
+	}
+	return costs
+}
+
+// bruteForce prices every permutation of the stops that keeps the sequence.
+func bruteForce(costs [][]float64, sequence []int) float64 {
+	stops := len(costs) - 1
+	predecessor := make([]int, stops+1)
+	for i := 1; i < len(sequence); i++ {
+		predecessor[sequence[i]] = sequence[i-1]
+	}
+
+	order := make([]int, stops)
+	for i := range order {
+		order[i] = i + 1
+	}
+	best := math.Inf(1)
+	var permute func(int)
+	permute = func(position int) {
+		if position == len(order) {
+			if follows(order, predecessor) {
+				best = math.Min(best, Cost(costs, order))
+			}
+			return
+		}
+		for i := position; i < len(order); i++ {
+			order[position], order[i] = order[i], order[position]
+			permute(position + 1)
+			order[position], order[i] = order[i], order[position]
+		}
+	}
+	permute(0)
+	return best
+}
+
+func TestSolveExact(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for stops := 1; stops <= 7; stops++ {
+		for round := 0; round < 5; round++ {
+			costs := randomCosts(random, stops)
+			var sequence []int
+			if stops >= 3 {
+				sequence = []int{3, 1}
+			}
+
+			plan := Solve(costs, sequence)
+			assert.True(t, plan.Exact)
+			assert.Len(t, plan.Order, stops)
+			assert.InDelta(t, bruteForce(costs, sequence), plan.Cost, 1e-9)
+			assert.InDelta(t, Cost(costs, plan.Order), plan.Cost, 1e-9)
+		}
+	}
+}
+
+func TestSolveHeuristic(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	costs := randomCosts(random, 40)
+	sequence := []int{40, 7, 22}
+
+	plan := Solve(costs, sequence)
+	assert.False(t, plan.Exact)
+	assert.ElementsMatch(t, func() []int {
+		all := make([]int, 40)
+		for i := range all {
+			all[i] = i + 1
+		}
+		return all
+	}(), plan.Order)
+
+	positions := map[int]int{}
+	for position, stop := range plan.Order {
+		positions[stop] = position
+	}
+	assert.Less(t, positions[40], positions[7])
+	assert.Less(t, positions[7], positions[22])
+	assert.InDelta(t, Cost(costs, plan.Order), plan.Cost, 1e-9)
+
+	predecessor := make([]int, 41)
+	predecessor[7], predecessor[22] = 40, 7
+	assert.LessOrEqual(t, plan.Cost, Cost(costs, nearestNeighbour(costs, predecessor)))
+}
+
+func TestSolveLine(t *testing.T) {
+	// stops on a line are cheapest visited outwards from the origin
+	distances := []float64{0, 30, 10, 20, 50, 40, 60, 90, 80, 70, 100, 120, 110, 130}
+	costs := make([][]float64, len(distances))
+	for i := range costs {
+		costs[i] = make([]float64, len(distances))
+		for j := range costs[i] {
+			costs[i][j] = math.Abs(distances[j] - distances[i])
+		}
+	}
+
+	plan := Solve(costs, nil)
+	assert.False(t, plan.Exact)
+	assert.Equal(t, []int{2, 3, 1, 5, 4, 6, 9, 8, 7, 10, 12, 11, 13}, plan.Order)
+	assert.Equal(t, 130.0, plan.Cost)
+}
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func captureLogs(t *testing.T, level slog.Level) *bytes.Buffer {
+	var buffer bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(New(&buffer, level))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buffer
+}
+
+func logLines(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to unmarshal log line %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	buffer := captureLogs(t, slog.LevelInfo)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), AccessLog())
+	router.GET("/planets", func (context *gin.Context) {
+		context.String(http.StatusOK, RequestIDFromContext(context.Request.Context()))
+	})
+
+	tests := []struct {
+		incoming string
+		propagated bool
+	}{
+		{"abc-123", true},
+		{"", false},
+		{"has spaces", false},
+		{strings.Repeat("x", 200), false},
+	}
+
+	for _, test := range tests {
+		buffer.Reset()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/planets", nil)
+		if test.incoming != "" {
+			req.Header.Set(RequestIDHeader, test.incoming)
+		}
+
+		router.ServeHTTP(w, req)
+		requestID := w.Header().Get(RequestIDHeader)
+		assert.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, w.Body.String())
+		if test.propagated {
+			assert.Equal(t, test.incoming, requestID)
+		} else {
+			assert.NotEqual(t, test.incoming, requestID)
+			assert.Len(t, requestID, 32)
+		}
+
+		lines := logLines(t, buffer)
+		if assert.Len(t, lines, 1) {
+			assert.Equal(t, requestID, lines[0]["request_id"])
+			assert.Equal(t, "/planets", lines[0]["route"])
+			assert.Equal(t, float64(http.StatusOK), lines[0]["status"])
+		}
+	}
+}
+
+func TestGormLogger(t *testing.T) {
+	buffer := captureLogs(t, slog.LevelDebug)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: NewGormLogger(time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	buffer.Reset()
+	db.WithContext(ctx).Exec("SELECT 1")
+	db.WithContext(ctx).Exec("SELECT * FROM missing_table")
+
+	lines := logLines(t, buffer)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, "query", lines[0]["msg"])
+		assert.Equal(t, "SELECT 1", lines[0]["sql"])
+		assert.Equal(t, "req-42", lines[0]["request_id"])
+		assert.Equal(t, "query failed", lines[1]["msg"])
+		assert.Equal(t, "ERROR", lines[1]["level"])
+		assert.Equal(t, "req-42", lines[1]["request_id"])
+	}
+
+	// every query is slow with a one-nanosecond threshold
+	db = db.Session(&gorm.Session{Logger: NewGormLogger(time.Nanosecond)})
+	buffer.Reset()
+	db.WithContext(ctx).Exec("SELECT 1")
+	lines = logLines(t, buffer)
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "slow query", lines[0]["msg"])
+		assert.Equal(t, "WARN", lines[0]["level"])
+	}
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the request ID between clients, this service and its logs.
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// RequestID propagates the incoming X-Request-ID, or generates one, and stores it in the request context.
+func RequestID() gin.HandlerFunc {
+	return func (context *gin.Context) {
+		requestID := context.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		context.Request = context.Request.WithContext(WithRequestID(context.Request.Context(), requestID))
+		context.Header(RequestIDHeader, requestID)
+		context.Next()
+	}
+}
+
+// AccessLog writes one structured log line per request once it has been handled.
+func AccessLog() gin.HandlerFunc {
+	return func (context *gin.Context) {
+		start := time.Now()
+		path := context.Request.URL.Path
+		query := context.Request.URL.RawQuery
+
+		context.Next()
+
+		status := context.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		} else if status >= 400 {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", context.Request.Method),
+			slog.String("path", path),
+			slog.String("route", context.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", context.ClientIP()),
+			slog.Int("bytes", context.Writer.Size()),
+		}
+		if query != "" {
+			attrs = append(attrs, slog.String("query", query))
+		}
+		if len(context.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", context.Errors.String()))
+		}
+		slog.LogAttrs(context.Request.Context(), level, "request handled", attrs...)
+	}
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		// only accept printable ASCII so IDs cannot inject into logs or headers
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return time.Now().UTC().Format("20060102T150405.000000000")
+	}
+	return hex.EncodeToString(buffer)
+}
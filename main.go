@@ -1,20 +1,146 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/grpcapi"
 	"github.com/kaitou-1412/Go-Space-Voyagers/initialize"
+	"github.com/kaitou-1412/Go-Space-Voyagers/logging"
+	"github.com/kaitou-1412/Go-Space-Voyagers/metrics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
 	"github.com/kaitou-1412/Go-Space-Voyagers/routes"
+	"github.com/kaitou-1412/Go-Space-Voyagers/tracing"
+	"github.com/kaitou-1412/Go-Space-Voyagers/webhooks"
+	"google.golang.org/grpc"
 )
 
 func init() {
 	initialize.LoadEnv()
+	logging.Setup(initialize.GetEnv("LOG_LEVEL", "info"))
 	database.ConnectToDB()
 }
 
 func main() {
-	server := gin.Default()
+	shutdownTracing, err := tracing.Setup(context.Background(), initialize.GetEnv("OTEL_TRACES_EXPORTER", "none"), initialize.GetEnv("OTEL_SERVICE_NAME", "go-space-voyagers"))
+	if err != nil {
+		logging.Fatal("Could not set up tracing.", "error", err)
+	}
+
+	server := gin.New()
+	server.Use(logging.RequestID(), tracing.Middleware(), logging.AccessLog(), problems.Recovery(), metrics.Middleware())
 	db := database.GetDB()
 	routes.RegisterRoutes(server, db)
-	server.Run()
+
+	httpServer := &http.Server{
+		Addr:              ":" + initialize.GetEnv("PORT", "8080"),
+		Handler:           server,
+		ReadHeaderTimeout: initialize.GetEnvDuration("READ_HEADER_TIMEOUT", 5*time.Second),
+		ReadTimeout:       initialize.GetEnvDuration("READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:      initialize.GetEnvDuration("WRITE_TIMEOUT", 15*time.Second),
+		IdleTimeout:       initialize.GetEnvDuration("IDLE_TIMEOUT", 60*time.Second),
+	}
+	// event streams never go idle, end them when shutting down
+	httpServer.RegisterOnShutdown(routes.CloseStreams)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		slog.Info("Listening.", "addr", httpServer.Addr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("Server failure.", "error", err)
+		}
+	}()
+
+	// the gRPC API is only served when a port is configured
+	var grpcServer *grpc.Server
+	if grpcPort := initialize.GetEnv("GRPC_PORT", ""); grpcPort != "" {
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			logging.Fatal("Could not listen for gRPC.", "error", err)
+		}
+		grpcServer = grpcapi.NewServer(db)
+		go func() {
+			slog.Info("Listening for gRPC.", "addr", listener.Addr().String())
+			if err := grpcServer.Serve(listener); err != nil {
+				logging.Fatal("gRPC server failure.", "error", err)
+			}
+		}()
+	}
+
+	// webhooks only reach public addresses unless their networks are listed, e.g. "10.0.0.0/8,fd00::/8"
+	var allowedNetworks []netip.Prefix
+	for _, network := range strings.Split(initialize.GetEnv("WEBHOOK_ALLOWED_NETWORKS", ""), ",") {
+		if network = strings.TrimSpace(network); network == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			logging.Fatal("Invalid WEBHOOK_ALLOWED_NETWORKS.", "error", err)
+		}
+		allowedNetworks = append(allowedNetworks, prefix)
+	}
+	dispatcher := webhooks.NewDispatcher(db,
+		webhooks.WithHTTPClient(&http.Client{Timeout: initialize.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second)}),
+		webhooks.WithAllowedNetworks(allowedNetworks...),
+		webhooks.WithRetries(
+			initialize.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			initialize.GetEnvDuration("WEBHOOK_RETRY_BASE", 10*time.Second),
+			initialize.GetEnvDuration("WEBHOOK_RETRY_MAX", time.Hour),
+		),
+	)
+	dispatched := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx)
+		close(dispatched)
+	}()
+
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutting down, draining in-flight requests.")
+
+	// stop accepting new connections and wait for in-flight requests to finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), initialize.GetEnvDuration("SHUTDOWN_TIMEOUT", 20*time.Second))
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Graceful shutdown failed.", "error", err)
+	}
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			slog.Error("Graceful gRPC shutdown timed out.")
+			grpcServer.Stop()
+		}
+	}
+
+	// let an in-flight webhook attempt record its outcome, or it is retried after its claim expires
+	select {
+	case <-dispatched:
+	case <-shutdownCtx.Done():
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Could not flush traces.", "error", err)
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
 }
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "voyagers"
+
+var (
+	// HTTPRequests counts handled requests by method, route template and status code.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes request latency by method and route template.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// DBQueryDuration observes the time spent in GORM operations by operation and table.
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Latency of database queries, by operation and table.",
+		Buckets:   []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	}, []string{"operation", "table"})
+
+	// PlanetsCreated counts planets added to the catalogue.
+	PlanetsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "planets_created_total",
+		Help:      "Number of planets created.",
+	})
+
+	// PlanetsDeleted counts planets removed from the catalogue.
+	PlanetsDeleted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "planets_deleted_total",
+		Help:      "Number of planets deleted.",
+	})
+
+	// FuelQuotes counts computed fuel cost estimations by planet type.
+	FuelQuotes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fuel_quotes_total",
+		Help:      "Number of fuel cost quotes computed, by planet type.",
+	}, []string{"planet_type"})
+
+	// WebhookDeliveries counts webhook delivery attempts by outcome: delivered, retry or dead.
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Number of webhook delivery attempts, by outcome.",
+	}, []string{"outcome"})
+)
+
+// Middleware records the request count and latency of every request passing through the router.
+func Middleware() gin.HandlerFunc {
+	return func (context *gin.Context) {
+		start := time.Now()
+		context.Next()
+
+		// use the route template rather than the raw path to keep label cardinality bounded
+		route := context.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := context.Request.Method
+		HTTPRequests.WithLabelValues(method, route, strconv.Itoa(context.Writer.Status())).Inc()
+		HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler exposes the collected metrics in the Prometheus text format.
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/planets/:id", func (context *gin.Context) {
+		context.Status(http.StatusNoContent)
+	})
+	router.GET("/metrics", Handler())
+
+	for _, endpoint := range []string{"/planets/1", "/planets/2", "/unknown"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", endpoint, nil)
+		router.ServeHTTP(w, req)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(HTTPRequests.WithLabelValues("GET", "/planets/:id", "204")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(HTTPRequests.WithLabelValues("GET", "unmatched", "404")))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `voyagers_http_request_duration_seconds_count{method="GET",route="/planets/:id"} 2`)
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	if err = db.Use(GormPlugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+
+	type probe struct {
+		ID   uint
+		Name string
+	}
+	if err = db.AutoMigrate(&probe{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	db.Create(&probe{Name: "a"})
+	var probes []probe
+	db.Find(&probes)
+
+	// one series per (operation, table) pair observed: create and query on probes at least
+	output, err := testutil.CollectAndFormat(DBQueryDuration, expfmt.TypeTextPlain, "voyagers_db_query_duration_seconds")
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+	assert.Contains(t, string(output), `voyagers_db_query_duration_seconds_count{operation="create",table="probes"} 1`)
+	assert.Contains(t, string(output), `voyagers_db_query_duration_seconds_count{operation="query",table="probes"} 1`)
+}
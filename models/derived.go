@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/physics"
+)
+
+// DerivedProperties are computed from the measurements of a planet, reading its radius and mass in Earth units.
+type DerivedProperties struct {
+	// SurfaceGravity is in m/s^2.
+	SurfaceGravity float64 `json:"surfaceGravity"`
+	// EscapeVelocity is in km/s.
+	EscapeVelocity float64 `json:"escapeVelocity"`
+	// Density is in g/cm^3.
+	Density float64 `json:"density"`
+	// EquilibriumTemperature is in kelvin, known for planets with a host star and a semi-major axis.
+	EquilibriumTemperature *float64 `json:"equilibriumTemperature"`
+}
+
+// Derive computes the derived properties of the planet, star is its host star or nil.
+func (planet Planet) Derive(star *Star) DerivedProperties {
+	derived := DerivedProperties{
+		SurfaceGravity: physics.SurfaceGravity(planet.Mass, planet.Radius),
+		EscapeVelocity: physics.EscapeVelocity(planet.Mass, planet.Radius),
+		Density:        physics.Density(planet.Mass, planet.Radius),
+	}
+	if star != nil && planet.SemiMajorAxis != nil {
+		temperature := physics.EquilibriumTemperature(star.Luminosity, *planet.SemiMajorAxis)
+		derived.EquilibriumTemperature = &temperature
+	}
+	return derived
+}
+
+// DerivedSQL maps the derived filter fields to their SQL expressions on the planets table. They call the physics
+// functions registered by the database driver, so filters and responses agree exactly.
+var DerivedSQL = map[string]string{
+	"surface_gravity": "surface_gravity(planets.mass, planets.radius)",
+	"escape_velocity": "escape_velocity(planets.mass, planets.radius)",
+	"density":         "density(planets.mass, planets.radius)",
+	"equilibrium_temperature": "(SELECT CASE WHEN planets.semi_major_axis IS NULL THEN NULL ELSE equilibrium_temperature(stars.luminosity, planets.semi_major_axis) END " +
+		"FROM stars WHERE stars.id = planets.star_id AND stars.deleted_at IS NULL)",
+}
+
+// EventPlanetFilters are the fields the change feed matches events on. Events carry the planet alone, so they leave
+// out the fields of the host star and the equilibrium temperature, which depends on it.
+var EventPlanetFilters = func() map[string]string {
+	filters := map[string]string{"surface_gravity": "float", "escape_velocity": "float", "density": "float"}
+	for field, dataType := range PlanetFilters {
+		if !strings.Contains(field, ".") {
+			filters[field] = dataType
+		}
+	}
+	return filters
+}()
+
+// DerivedPlanetFilters are the PlanetFilters plus the derived properties.
+var DerivedPlanetFilters = func() map[string]string {
+	filters := map[string]string{}
+	for field, dataType := range PlanetFilters {
+		filters[field] = dataType
+	}
+	for field := range DerivedSQL {
+		filters[field] = "float"
+	}
+	return filters
+}()
@@ -0,0 +1,17 @@
+package models
+
+// Itinerary is a trip from Earth visiting several planets, without returning.
+type Itinerary struct {
+	Crew          int64   `json:"crew"`
+	Legs          []Leg   `json:"legs"`
+	TotalFuelCost float64 `json:"totalFuelCost"`
+	// Exact tells whether no cheaper order exists, large itineraries are planned with a heuristic.
+	Exact bool `json:"exact"`
+}
+
+// Leg is the travel to one planet of an itinerary from the previous stop, Earth when FromPlanetID is nil.
+type Leg struct {
+	FromPlanetID *uint   `json:"fromPlanetId"`
+	Planet       Planet  `json:"planet"`
+	FuelCost     float64 `json:"fuelCost"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// Moon is a natural satellite of a planet. Radius and mass use the units of planets,
+// the orbital distance is measured in radii of the parent planet.
+type Moon struct {
+	gorm.Model
+	PlanetID        uint    `gorm:"index" json:"planetId"`
+	Name            string  `binding:"required" json:"name"`
+	Radius          float64 `binding:"required" json:"radius"`
+	Mass            float64 `binding:"required" json:"mass"`
+	OrbitalDistance float64 `binding:"required" json:"orbitalDistance"`
+}
+
+var MoonFilters = map[string]string{
+	"id":               "int",
+	"name":             "string",
+	"radius":           "float",
+	"mass":             "float",
+	"orbital_distance": "float",
+}
+
+// Validate checks that the moon is smaller and lighter than its parent planet and orbits above its surface.
+func (moon Moon) Validate(parent Planet) error {
+	if !(0 < moon.Radius && moon.Radius < parent.Radius) {
+		return &ValidationError{Field: "radius", Message: "Radius should be positive and smaller than the radius of the planet."}
+	}
+
+	if !(0 < moon.Mass && moon.Mass < parent.Mass) {
+		return &ValidationError{Field: "mass", Message: "Mass should be positive and smaller than the mass of the planet."}
+	}
+
+	if !(1 < moon.OrbitalDistance) {
+		return &ValidationError{Field: "orbitalDistance", Message: "Orbital distance should be more than one radius of the planet."}
+	}
+
+	return nil
+}
+
+// GetDescentFuelCost calculates the fuel cost required to travel from the parent planet down to the moon
+// with the given crew capacity, following the same rule as GetFuelCost for planets.
+func (moon Moon) GetDescentFuelCost(crewCapacity int64) float64 {
+	gravity := moon.Mass / math.Pow(moon.Radius, 2)
+	return moon.OrbitalDistance / math.Pow(gravity, 2) * float64(crewCapacity)
+}
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
+	"github.com/kaitou-1412/Go-Space-Voyagers/units"
 	"gorm.io/gorm"
 )
 
@@ -14,6 +18,17 @@ type Planet struct {
 	Radius      float64 `binding:"required" json:"radius"`
 	Mass        float64 `json:"mass"`
 	Type        PlanetType `binding:"required" json:"type"`
+	StarID      *uint   `gorm:"index" json:"starId,omitempty"`
+	// DistanceError, RadiusError and MassError are the standard deviations of the measurements, when known.
+	DistanceError *float64 `json:"distanceError,omitempty"`
+	RadiusError   *float64 `json:"radiusError,omitempty"`
+	MassError     *float64 `json:"massError,omitempty"`
+	// SemiMajorAxis is the radius of the orbit around the host star, in astronomical units.
+	SemiMajorAxis *float64 `json:"semiMajorAxis,omitempty"`
+	// Kind is the definition of Type, set by PlanetTypeRegistry.Resolve. Built-in types do not need it.
+	Kind *PlanetTypeDefinition `gorm:"-" json:"-"`
+	// Derived is only set on responses that ask for the derived properties.
+	Derived *DerivedProperties `gorm:"-" json:"derived,omitempty"`
 }
 
 type PlanetType string
@@ -31,16 +46,227 @@ var PlanetFilters = map[string]string{
     "radius": "float",
     "mass": "float",
 	"type": "string",
+	"star_id": "int",
+	"star.name": "string",
+	"star.spectral_type": "string",
+	"star.mass": "float",
+	"star.luminosity": "float",
+	"star.distance": "float",
+	"star.star_system_id": "int",
+}
+
+// PlanetRelations are the joins behind the "star." planet filters.
+var PlanetRelations = map[string]string{
+	"star": "LEFT JOIN stars star ON star.id = planets.star_id AND star.deleted_at IS NULL",
+}
+
+// FilterValues returns the planet fields and derived properties keyed by the names of EventPlanetFilters.
+func (planet Planet) FilterValues() map[string]interface{} {
+	// a planet without a star has a NULL star_id, which no condition matches
+	var starID interface{}
+	if planet.StarID != nil {
+		starID = *planet.StarID
+	}
+	derived := planet.Derive(nil)
+	return map[string]interface{}{
+		"id":              planet.ID,
+		"name":            planet.Name,
+		"description":     planet.Description,
+		"distance":        planet.Distance,
+		"radius":          planet.Radius,
+		"mass":            planet.Mass,
+		"type":            string(planet.Type),
+		"star_id":         starID,
+		"surface_gravity": derived.SurfaceGravity,
+		"escape_velocity": derived.EscapeVelocity,
+		"density":         derived.Density,
+	}
+}
+
+// PlanetUnits are the canonical units planet measurements are stored in, by JSON field. Their errors use the same unit.
+// Distances are whole light years from Earth.
+var PlanetUnits = map[string]units.Unit{
+	"distance":      units.LightYear,
+	"radius":        units.EarthRadius,
+	"mass":          units.EarthMass,
+	"semiMajorAxis": units.AstronomicalUnit,
+}
+
+// DistanceRange is the catalogue range of planet distances, radius and mass ranges depend on the planet type.
+var DistanceRange = units.Range{Min: 10, Max: 1000, Unit: units.LightYear}
+
+// ValidationError describes why a planet is not acceptable for the catalogue.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (err *ValidationError) Error() string {
+	return err.Message
+}
+
+// definition returns the resolved definition of the planet type, or the built-in one.
+func (planet Planet) definition() (PlanetTypeDefinition, bool) {
+	if planet.Kind != nil && planet.Kind.Name == planet.Type {
+		return *planet.Kind, true
+	}
+	return builtInTypes.Get(planet.Type)
+}
+
+// kind returns the definition of the planet type, planets of an unknown type use their own mass.
+func (planet Planet) kind() PlanetTypeDefinition {
+	if kind, ok := planet.definition(); ok {
+		return kind
+	}
+	return PlanetTypeDefinition{Name: planet.Type, GravityModel: MassGravity}
+}
+
+// Validate checks the planet against the catalogue distances and the ranges of its planet type, which must be
+// built in or resolved.
+func (planet Planet) Validate() error {
+	if !DistanceRange.Contains(float64(planet.Distance), PlanetUnits["distance"]) {
+		return &ValidationError{Field: "distance", Message: "Distance should be " + DistanceRange.String() + "."}
+	}
+
+	kind, ok := planet.definition()
+	if !ok {
+		return &ValidationError{Field: "type", Message: "Invalid planet type."}
+	}
+
+	if !kind.RadiusRange().Contains(planet.Radius, PlanetUnits["radius"]) {
+		return &ValidationError{Field: "radius", Message: "Radius should be " + kind.RadiusRange().String() + "."}
+	}
+
+	if !kind.MassRange().Contains(planet.Mass, PlanetUnits["mass"]) {
+		return &ValidationError{Field: "mass", Message: "Mass should be " + kind.MassRange().String() + "."}
+	}
+
+	// an error beyond the measurement makes sampled fuel costs meaningless, or infinite
+	for _, uncertainty := range []struct {
+		field       string
+		value       *float64
+		measurement float64
+		name        string
+	}{{"distanceError", planet.DistanceError, float64(planet.Distance), "Distance"}, {"radiusError", planet.RadiusError, planet.Radius, "Radius"}, {"massError", planet.MassError, planet.Mass, "Mass"}} {
+		if uncertainty.value == nil {
+			continue
+		}
+		if !(*uncertainty.value >= 0) {
+			return &ValidationError{Field: uncertainty.field, Message: uncertainty.name + " error should not be negative."}
+		}
+		if !(*uncertainty.value <= uncertainty.measurement) {
+			return &ValidationError{Field: uncertainty.field, Message: uncertainty.name + " error should not exceed the " + strings.ToLower(uncertainty.name) + "."}
+		}
+	}
+
+	if planet.SemiMajorAxis != nil && !(*planet.SemiMajorAxis > 0) {
+		return &ValidationError{Field: "semiMajorAxis", Message: "Semi-major axis should be positive."}
+	}
+
+	return nil
 }
 
 // GetFuelCost calculates the fuel cost required to travel to the planet with the given crew capacity.
 func (planet Planet) GetFuelCost(crewCapacity int64) float64 {
-	var gravity float64
-	if planet.Type == GasGiant {
-		gravity = 0.5 / math.Pow(float64(planet.Radius), 2)
+	return planet.GetFuelCostFrom(0, crewCapacity)
+}
+
+// GetFuelCostFrom calculates the fuel cost required to travel to the planet from a body at the given distance
+// from Earth, such as the previous stop of an itinerary. Earth itself is at distance 0.
+func (planet Planet) GetFuelCostFrom(origin int64, crewCapacity int64) float64 {
+	distance := planet.Distance - origin
+	if distance < 0 {
+		distance = -distance
+	}
+	return float64(distance) / math.Pow(planet.Gravity(), 2) * float64(crewCapacity)
+}
+
+// Gravity is the surface gravity used by the fuel cost, mass / radius^2 with the mass given by the gravity model
+// of the planet type, such as the fixed mass of gas giants.
+func (planet Planet) Gravity() float64 {
+	return planet.kind().gravityMass(planet) / math.Pow(float64(planet.Radius), 2)
+}
+
+// FuelCostModelVersion identifies the fuel cost formula, it changes whenever quotes for the same inputs would.
+const FuelCostModelVersion = "1"
+
+// lowGravity is the gravity under which quotes are flagged, the cost grows with the inverse square of the gravity.
+const lowGravity = 0.005
+
+// FuelCostBreakdown lists the terms of a fuel cost quote: distance / gravity^2 * crew.
+type FuelCostBreakdown struct {
+	ModelVersion string  `json:"modelVersion"`
+	FuelCost     float64 `json:"fuelCost"`
+	Gravity      float64 `json:"gravity"`
+	// GravityDerivation describes how the gravity was obtained from the planet.
+	GravityDerivation string   `json:"gravityDerivation"`
+	Distance          int64    `json:"distance"`
+	DistanceFactor    float64  `json:"distanceFactor"`
+	CrewMultiplier    int64    `json:"crewMultiplier"`
+	Warnings          []string `json:"warnings"`
+}
+
+// ExplainFuelCost returns the terms of GetFuelCost for the given crew capacity, with warnings about unreliable inputs.
+func (planet Planet) ExplainFuelCost(crewCapacity int64) FuelCostBreakdown {
+	breakdown := FuelCostBreakdown{
+		ModelVersion:   FuelCostModelVersion,
+		FuelCost:       planet.GetFuelCost(crewCapacity),
+		Gravity:        planet.Gravity(),
+		Distance:       planet.Distance,
+		CrewMultiplier: crewCapacity,
+		Warnings:       []string{},
+	}
+	breakdown.DistanceFactor = float64(planet.Distance) / math.Pow(breakdown.Gravity, 2)
+
+	if kind := planet.kind(); kind.GravityModel == FixedMassGravity {
+		fixed := kind.gravityMass(planet)
+		breakdown.GravityDerivation = fmt.Sprintf("%g / radius^2 with radius %g, %ss use a fixed mass of %g", fixed, planet.Radius, kind.DisplayName(), fixed)
+		if planet.Mass != fixed {
+			breakdown.GravityDerivation += fmt.Sprintf(" instead of their mass of %g", planet.Mass)
+		}
 	} else {
-		gravity = float64(planet.Mass) / math.Pow(float64(planet.Radius), 2)
+		breakdown.GravityDerivation = fmt.Sprintf("mass / radius^2 with mass %g and radius %g", planet.Mass, planet.Radius)
+	}
+
+	if breakdown.Gravity < lowGravity {
+		breakdown.Warnings = append(breakdown.Warnings, fmt.Sprintf("Gravity %.3g is close to zero, the fuel cost grows with its inverse square and is unreliable.", breakdown.Gravity))
+	}
+	if math.IsInf(breakdown.FuelCost, 0) || math.IsNaN(breakdown.FuelCost) {
+		breakdown.Warnings = append(breakdown.Warnings, "The fuel cost is not a finite number.")
+	}
+	return breakdown
+}
+
+// PricedPlanet is a planet with the fuel cost of a trip to it.
+type PricedPlanet struct {
+	Planet
+	FuelCost float64 `json:"fuel_cost"`
+}
 
+// PricedPlanetFilters are the DerivedPlanetFilters plus the fuel cost of listings priced for a crew.
+var PricedPlanetFilters = func() map[string]string {
+	filters := map[string]string{"fuel_cost": "float"}
+	for field, dataType := range DerivedPlanetFilters {
+		filters[field] = dataType
+	}
+	return filters
+}()
+
+// FuelCostSQL is the SQL expression of GetFuelCost on the planets table, so that queries can filter and sort on it.
+// It evaluates the same floating point operations in the same order, squares included, so both agree exactly.
+// kinds are the planet types whose gravity models apply, such as those of a PlanetTypeRegistry.
+func FuelCostSQL(crewCapacity int64, kinds []PlanetTypeDefinition) string {
+	mass := "planets.mass"
+	var fixed []string
+	for _, kind := range kinds {
+		if kind.GravityModel == FixedMassGravity {
+			// names are validated as lower case letters, digits and underscores
+			fixed = append(fixed, fmt.Sprintf("WHEN planets.type = '%s' THEN %s", kind.Name, strconv.FormatFloat(*kind.GravityMass, 'g', -1, 64)))
+		}
+	}
+	if len(fixed) > 0 {
+		mass = "CASE " + strings.Join(fixed, " ") + " ELSE planets.mass END"
 	}
-	return float64(planet.Distance) / math.Pow(gravity, 2) * float64(crewCapacity)
+	gravity := fmt.Sprintf("(%s / (planets.radius * planets.radius))", mass)
+	return fmt.Sprintf("(planets.distance / (%s * %s) * %d)", gravity, gravity, crewCapacity)
 }
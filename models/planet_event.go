@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type PlanetEventType string
+
+const (
+	PlanetCreated PlanetEventType = "created"
+	PlanetUpdated PlanetEventType = "updated"
+	PlanetDeleted PlanetEventType = "deleted"
+)
+
+// PlanetEvent is an entry of the persisted planet change log, its ID orders the feed and resumes it.
+type PlanetEvent struct {
+	ID         uint            `gorm:"primarykey" json:"id"`
+	Type       PlanetEventType `gorm:"not null" json:"type"`
+	PlanetID   uint            `gorm:"index" json:"planetId"`
+	Planet     Planet          `gorm:"serializer:json" json:"planet"`
+	OccurredAt time.Time       `gorm:"autoCreateTime" json:"occurredAt"`
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/units"
+)
+
+// GravityModel is how the surface gravity used by the fuel cost is obtained from a planet.
+type GravityModel string
+
+const (
+	// MassGravity is mass / radius^2.
+	MassGravity GravityModel = "mass"
+	// FixedMassGravity is GravityMass / radius^2, ignoring the mass of the planet.
+	FixedMassGravity GravityModel = "fixed_mass"
+)
+
+// PlanetTypeDefinition declares a planet type, the ranges its planets are validated against and how their
+// fuel cost gravity is obtained. Ranges are exclusive, in Earth radii and Earth masses.
+type PlanetTypeDefinition struct {
+	Name PlanetType `gorm:"primaryKey" json:"name"`
+	// Label is the human readable name, such as "gas giant", the name with spaces by default.
+	Label        string       `json:"label"`
+	Description  string       `json:"description"`
+	MinRadius    float64      `json:"minRadius"`
+	MaxRadius    float64      `binding:"required" json:"maxRadius"`
+	MinMass      float64      `json:"minMass"`
+	MaxMass      float64      `binding:"required" json:"maxMass"`
+	GravityModel GravityModel `binding:"required" json:"gravityModel"`
+	// GravityMass replaces the mass of the planets in their gravity under the fixed_mass model.
+	GravityMass *float64 `json:"gravityMass,omitempty"`
+	// BuiltIn types are defined in code and cannot be changed.
+	BuiltIn bool `gorm:"-" json:"builtIn"`
+	// UpdatedAt tells the servers sharing the database that the stored types changed.
+	UpdatedAt time.Time `json:"-"`
+}
+
+func (PlanetTypeDefinition) TableName() string {
+	return "planet_types"
+}
+
+const (
+	IceGiant   PlanetType = "ice_giant"
+	SuperEarth PlanetType = "super_earth"
+	Dwarf      PlanetType = "dwarf"
+	OceanWorld PlanetType = "ocean_world"
+)
+
+func earthMasses(value float64) *float64 {
+	return &value
+}
+
+// BuiltInPlanetTypes are always registered. Gas giants keep their historical fuel cost, their gravity uses a fixed
+// mass of 0.5.
+var BuiltInPlanetTypes = []PlanetTypeDefinition{
+	{Name: Terrestrial, Label: "terrestrial planet", Description: "A rocky planet.", MinRadius: 0.1, MaxRadius: 10, MinMass: 0.1, MaxMass: 10, GravityModel: MassGravity},
+	{Name: GasGiant, Label: "gas giant", Description: "A giant planet mostly made of hydrogen and helium.", MinRadius: 0.1, MaxRadius: 10, MinMass: 0.1, MaxMass: 10, GravityModel: FixedMassGravity, GravityMass: earthMasses(0.5)},
+	{Name: IceGiant, Label: "ice giant", Description: "A giant planet mostly made of water, ammonia and methane ices.", MinRadius: 2, MaxRadius: 8, MinMass: 5, MaxMass: 50, GravityModel: MassGravity},
+	{Name: SuperEarth, Label: "super-Earth", Description: "A rocky planet heavier than Earth but lighter than the ice giants.", MinRadius: 1, MaxRadius: 2, MinMass: 1, MaxMass: 10, GravityModel: MassGravity},
+	{Name: Dwarf, Label: "dwarf planet", Description: "A body massive enough to be round that has not cleared its orbit.", MinRadius: 0.01, MaxRadius: 0.5, MinMass: 0.0001, MaxMass: 0.1, GravityModel: MassGravity},
+	{Name: OceanWorld, Label: "ocean world", Description: "A planet covered by a deep global ocean.", MinRadius: 0.5, MaxRadius: 3, MinMass: 0.1, MaxMass: 10, GravityModel: MassGravity},
+}
+
+// builtInTypes resolves the built-in types of planets no registry resolved.
+var builtInTypes = NewPlanetTypeRegistry()
+
+// planetTypeName keeps type names usable in URLs and SQL literals.
+var planetTypeName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Validate checks the name, ranges and gravity model of a planet type.
+func (kind PlanetTypeDefinition) Validate() error {
+	if !planetTypeName.MatchString(string(kind.Name)) {
+		return &ValidationError{Field: "name", Message: "Name should be up to 32 lower case letters, digits and underscores, starting with a letter."}
+	}
+
+	if !(0 <= kind.MinRadius && kind.MinRadius < kind.MaxRadius) {
+		return &ValidationError{Field: "maxRadius", Message: "Radius range should not be negative and minRadius should be smaller than maxRadius."}
+	}
+
+	if !(0 <= kind.MinMass && kind.MinMass < kind.MaxMass) {
+		return &ValidationError{Field: "maxMass", Message: "Mass range should not be negative and minMass should be smaller than maxMass."}
+	}
+
+	switch kind.GravityModel {
+	case MassGravity:
+		if kind.GravityMass != nil {
+			return &ValidationError{Field: "gravityMass", Message: "Gravity mass only applies to the fixed_mass gravity model."}
+		}
+	case FixedMassGravity:
+		if kind.GravityMass == nil || !(*kind.GravityMass > 0) {
+			return &ValidationError{Field: "gravityMass", Message: "Gravity mass should be positive with the fixed_mass gravity model."}
+		}
+	default:
+		return &ValidationError{Field: "gravityModel", Message: "Gravity model should be mass or fixed_mass."}
+	}
+
+	return nil
+}
+
+// RadiusRange is the range of the radius of planets of the type.
+func (kind PlanetTypeDefinition) RadiusRange() units.Range {
+	return units.Range{Min: kind.MinRadius, Max: kind.MaxRadius, Unit: units.EarthRadius}
+}
+
+// MassRange is the range of the mass of planets of the type.
+func (kind PlanetTypeDefinition) MassRange() units.Range {
+	return units.Range{Min: kind.MinMass, Max: kind.MaxMass, Unit: units.EarthMass}
+}
+
+// DisplayName is the label of the type, or its name with spaces when it has none.
+func (kind PlanetTypeDefinition) DisplayName() string {
+	if kind.Label != "" {
+		return kind.Label
+	}
+	return strings.ReplaceAll(string(kind.Name), "_", " ")
+}
+
+// gravityMass is the mass the fuel cost gravity of the planet is computed from.
+func (kind PlanetTypeDefinition) gravityMass(planet Planet) float64 {
+	if kind.GravityModel == FixedMassGravity && kind.GravityMass != nil {
+		return *kind.GravityMass
+	}
+	return planet.Mass
+}
+
+// PlanetTypeRegistry holds the built-in planet types and those stored in the database by name. It does not change
+// once built, so it is safe for concurrent use.
+type PlanetTypeRegistry struct {
+	builtIn []PlanetType
+	types   map[PlanetType]PlanetTypeDefinition
+}
+
+// NewPlanetTypeRegistry returns a registry of the built-in types and the given stored types, which cannot replace them.
+func NewPlanetTypeRegistry(stored ...PlanetTypeDefinition) *PlanetTypeRegistry {
+	registry := &PlanetTypeRegistry{types: map[PlanetType]PlanetTypeDefinition{}}
+	for _, kind := range BuiltInPlanetTypes {
+		kind.BuiltIn = true
+		registry.builtIn = append(registry.builtIn, kind.Name)
+		registry.types[kind.Name] = kind
+	}
+	for _, kind := range stored {
+		if _, taken := registry.types[kind.Name]; !taken {
+			kind.BuiltIn = false
+			registry.types[kind.Name] = kind
+		}
+	}
+	return registry
+}
+
+// Get returns the type with the given name.
+func (registry *PlanetTypeRegistry) Get(name PlanetType) (PlanetTypeDefinition, bool) {
+	kind, ok := registry.types[name]
+	return kind, ok
+}
+
+// List returns the built-in types in their declared order, then the other types by name.
+func (registry *PlanetTypeRegistry) List() []PlanetTypeDefinition {
+	kinds := make([]PlanetTypeDefinition, 0, len(registry.types))
+	for _, name := range registry.builtIn {
+		kinds = append(kinds, registry.types[name])
+	}
+	custom := []PlanetTypeDefinition{}
+	for _, kind := range registry.types {
+		if !kind.BuiltIn {
+			custom = append(custom, kind)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Name < custom[j].Name })
+	return append(kinds, custom...)
+}
+
+// Resolve sets the definition of the type of every planet, planets of an unknown type get none.
+func (registry *PlanetTypeRegistry) Resolve(planets ...*Planet) {
+	for _, planet := range planets {
+		planet.Kind = nil
+		if kind, ok := registry.Get(planet.Type); ok {
+			planet.Kind = &kind
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import "gorm.io/gorm"
+
+// Star hosts planets. Mass and luminosity are in solar units, distance in light years from Earth.
+type Star struct {
+	gorm.Model
+	Name         string       `binding:"required" json:"name"`
+	SpectralType SpectralType `binding:"required" json:"spectralType"`
+	Mass         float64      `binding:"required" json:"mass"`
+	Luminosity   float64      `binding:"required" json:"luminosity"`
+	Distance     float64      `json:"distance"`
+	StarSystemID *uint        `gorm:"index" json:"starSystemId,omitempty"`
+}
+
+// SpectralType is the Morgan-Keenan class of a star, from the hottest O to the coolest M.
+type SpectralType string
+
+var SpectralTypes = []SpectralType{"O", "B", "A", "F", "G", "K", "M"}
+
+var StarFilters = map[string]string{
+	"id":             "int",
+	"name":           "string",
+	"spectral_type":  "string",
+	"mass":           "float",
+	"luminosity":     "float",
+	"distance":       "float",
+	"star_system_id": "int",
+}
+
+// Validate checks the star against physically plausible ranges and known spectral types.
+func (star Star) Validate() error {
+	known := false
+	for _, spectralType := range SpectralTypes {
+		known = known || star.SpectralType == spectralType
+	}
+	if !known {
+		return &ValidationError{Field: "spectralType", Message: "Spectral type should be one of O, B, A, F, G, K or M."}
+	}
+
+	if !(0 < star.Mass && star.Mass <= 300) {
+		return &ValidationError{Field: "mass", Message: "Mass should be between 0 and 300 solar masses."}
+	}
+
+	if !(0 < star.Luminosity) {
+		return &ValidationError{Field: "luminosity", Message: "Luminosity should be positive."}
+	}
+
+	if star.Distance < 0 {
+		return &ValidationError{Field: "distance", Message: "Distance should not be negative."}
+	}
+
+	return nil
+}
+
+// StarSystem groups the stars of a gravitationally bound system, such as a binary.
+type StarSystem struct {
+	gorm.Model
+	Name        string `binding:"required" json:"name"`
+	Description string `json:"description"`
+}
+
+var StarSystemFilters = map[string]string{
+	"id":          "int",
+	"name":        "string",
+	"description": "string",
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// maxResamples bounds the draws spent on one truncated sample before it is clamped to the nearest valid value.
+const maxResamples = 100
+
+// MonteCarlo configures a sampled fuel cost estimate.
+type MonteCarlo struct {
+	Samples int
+	// Seed makes estimates reproducible, equal seeds and inputs give equal estimates.
+	Seed int64
+	// Percentiles are in [0, 100].
+	Percentiles []float64
+}
+
+// FuelCostEstimate summarises the fuel costs sampled from the measurement uncertainties of a planet.
+type FuelCostEstimate struct {
+	Samples int     `json:"samples"`
+	Seed    int64   `json:"seed"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"stdDev"`
+	// Percentiles maps each requested percentile, formatted like "95" or "2.5", to its fuel cost.
+	Percentiles map[string]float64 `json:"percentiles"`
+}
+
+// EstimateFuelCost propagates the measurement errors of the planet to its fuel cost by sampling distance, radius
+// and mass from normal distributions truncated to positive values. Measurements without an error are exact, and
+// the mass of planet types with a fixed gravity mass, such as gas giants, is not sampled as the fuel cost does not use it.
+func (planet Planet) EstimateFuelCost(crewCapacity int64, options MonteCarlo) FuelCostEstimate {
+	random := rand.New(rand.NewSource(options.Seed))
+	mass := func() float64 { return sample(random, planet.Mass, planet.MassError) }
+	if kind := planet.kind(); kind.GravityModel == FixedMassGravity {
+		mass = func() float64 { return kind.gravityMass(planet) }
+	}
+
+	costs := make([]float64, options.Samples)
+	sum := 0.0
+	for i := range costs {
+		distance := sample(random, float64(planet.Distance), planet.DistanceError)
+		radius := sample(random, planet.Radius, planet.RadiusError)
+		gravity := mass() / math.Pow(radius, 2)
+		costs[i] = distance / math.Pow(gravity, 2) * float64(crewCapacity)
+		sum += costs[i]
+	}
+
+	estimate := FuelCostEstimate{Samples: options.Samples, Seed: options.Seed, Percentiles: map[string]float64{}}
+	if len(costs) == 0 {
+		return estimate
+	}
+	estimate.Mean = sum / float64(len(costs))
+	squares := 0.0
+	for _, cost := range costs {
+		squares += (cost - estimate.Mean) * (cost - estimate.Mean)
+	}
+	if len(costs) > 1 {
+		estimate.StdDev = math.Sqrt(squares / float64(len(costs)-1))
+	}
+
+	sort.Float64s(costs)
+	for _, percentile := range options.Percentiles {
+		estimate.Percentiles[strconv.FormatFloat(percentile, 'f', -1, 64)] = Percentile(costs, percentile)
+	}
+	return estimate
+}
+
+// sample draws a positive value around mean with the standard deviation stdDev, mean itself when stdDev is unknown or 0.
+func sample(random *rand.Rand, mean float64, stdDev *float64) float64 {
+	if stdDev == nil || *stdDev == 0 {
+		return mean
+	}
+	for i := 0; i < maxResamples; i++ {
+		if value := mean + random.NormFloat64()**stdDev; value > 0 {
+			return value
+		}
+	}
+	return math.SmallestNonzeroFloat64
+}
+
+// Percentile interpolates linearly between the closest ranks of sorted, p is in [0, 100].
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook subscribes a URL to planet events, deliveries are signed with its secret.
+// An empty Events list subscribes to every event type.
+type Webhook struct {
+	gorm.Model
+	URL    string            `binding:"required,url" json:"url"`
+	Events []PlanetEventType `gorm:"serializer:json" json:"events"`
+	Secret string            `binding:"required,min=16" json:"-"`
+}
+
+// Subscribes reports whether events of the given type are delivered to the webhook.
+func (webhook Webhook) Subscribes(eventType PlanetEventType) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range webhook.Events {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is an outbox entry sending one planet event to one webhook.
+// It is written in the transaction that changes the planet and retried until delivered or dead.
+type WebhookDelivery struct {
+	ID             uint           `gorm:"primarykey" json:"id"`
+	WebhookID      uint           `gorm:"index;not null" json:"webhookId"`
+	Webhook        Webhook        `json:"-"`
+	EventID        uint           `gorm:"not null" json:"eventId"`
+	Event          PlanetEvent    `json:"event"`
+	Status         DeliveryStatus `gorm:"index:idx_webhook_deliveries_due,priority:1;not null" json:"status"`
+	NextAttemptAt  time.Time      `gorm:"index:idx_webhook_deliveries_due,priority:2" json:"nextAttemptAt"`
+	Attempts       int            `json:"attempts"`
+	LastStatusCode int            `json:"lastStatusCode,omitempty"`
+	LastError      string         `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time     `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
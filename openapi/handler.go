@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+var (
+	//go:embed ui/index.html
+	uiIndex []byte
+	//go:embed ui/initializer.js
+	uiInitializer []byte
+)
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// SpecHandler serves the generated OpenAPI document.
+func SpecHandler() gin.HandlerFunc {
+	return func (context *gin.Context) {
+		specOnce.Do(func() {
+			specJSON, specErr = json.MarshalIndent(Build(), "", "  ")
+		})
+		if specErr != nil {
+			context.AbortWithError(http.StatusInternalServerError, specErr)
+			return
+		}
+		context.Data(http.StatusOK, "application/json; charset=utf-8", specJSON)
+	}
+}
+
+// RegisterUI serves an offline Swagger UI for the document at /docs, its assets are embedded in the binary.
+func RegisterUI(server *gin.Engine) {
+	assets := http.StripPrefix("/docs/assets", http.FileServer(http.FS(swaggerFiles.FS)))
+
+	server.GET("/docs", func (context *gin.Context) {
+		context.Data(http.StatusOK, "text/html; charset=utf-8", uiIndex)
+	})
+	server.GET("/docs/assets/*filepath", func (context *gin.Context) {
+		if context.Param("filepath") == "/initializer.js" {
+			context.Data(http.StatusOK, "application/javascript; charset=utf-8", uiInitializer)
+			return
+		}
+		assets.ServeHTTP(context.Writer, context.Request)
+	})
+}
@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schema is a JSON Schema object as used by OpenAPI 3.1.
+type Schema map[string]interface{}
+
+func ref(name string) Schema {
+	return Schema{"$ref": "#/components/schemas/" + name}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+)
+
+// schemaGenerator derives schemas from Go types, collecting named structs as reusable components.
+type schemaGenerator struct {
+	components map[string]Schema
+	enums      map[reflect.Type][]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{components: map[string]Schema{}, enums: map[reflect.Type][]string{}}
+}
+
+// component registers the struct type of value under name and returns a reference to it.
+func (g *schemaGenerator) component(name string, value interface{}) Schema {
+	if _, exists := g.components[name]; !exists {
+		// reserve the name first so self-referencing types terminate
+		g.components[name] = Schema{}
+		g.components[name] = g.structSchema(reflect.TypeOf(value))
+	}
+	return ref(name)
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) Schema {
+	if values, ok := g.enums[t]; ok {
+		return Schema{"type": "string", "enum": values}
+	}
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t == deletedAtType:
+		return Schema{"type": []string{"string", "null"}, "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := g.schemaFor(t.Elem())
+		if kind, ok := schema["type"].(string); ok {
+			schema["type"] = []string{kind, "null"}
+		}
+		return schema
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if _, exists := g.components[t.Name()]; exists && t.Name() != "" {
+			return ref(t.Name())
+		}
+		return g.structSchema(t)
+	default:
+		// interface{} values accept any JSON value
+		return Schema{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) Schema {
+	properties := Schema{}
+	var required []string
+	g.collectFields(t, properties, &required)
+
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *schemaGenerator) collectFields(t reflect.Type, properties Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// embedded structs without a json name are flattened the way encoding/json does
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.collectFields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.schemaFor(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") && !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
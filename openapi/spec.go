@@ -0,0 +1,817 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+)
+
+// Version of the API described by the document.
+const Version = "1.0.0"
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Tags       []Tag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas   map[string]Schema    `json:"schemas"`
+	Responses map[string]Response `json:"responses,omitempty"`
+}
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string               `json:"name"`
+	In          string               `json:"in"`
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Schema      Schema               `json:"schema,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Route identifies an operation by HTTP method and gin path template.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// route describes one documented operation.
+type route struct {
+	Route
+	Operation Operation
+}
+
+// Build generates the OpenAPI document for every documented route.
+func Build() Document {
+	generator := newSchemaGenerator()
+	generator.enums[reflect.TypeOf(models.PlanetEventType(""))] = []string{string(models.PlanetCreated), string(models.PlanetUpdated), string(models.PlanetDeleted)}
+	generator.component("DerivedProperties", models.DerivedProperties{})
+	generator.component("Planet", models.Planet{})
+	generator.component("PricedPlanet", models.PricedPlanet{})
+	generator.component("FuelCostBreakdown", models.FuelCostBreakdown{})
+	generator.component("FuelCostEstimate", models.FuelCostEstimate{})
+	generator.component("PlanetTypeDefinition", models.PlanetTypeDefinition{})
+	generator.component("Moon", models.Moon{})
+	generator.component("Itinerary", models.Itinerary{})
+	spectralTypes := make([]string, len(models.SpectralTypes))
+	for i, spectralType := range models.SpectralTypes {
+		spectralTypes[i] = string(spectralType)
+	}
+	generator.enums[reflect.TypeOf(models.SpectralType(""))] = spectralTypes
+	generator.component("Star", models.Star{})
+	generator.component("StarSystem", models.StarSystem{})
+	generator.component("PlanetEvent", models.PlanetEvent{})
+	generator.enums[reflect.TypeOf(models.DeliveryStatus(""))] = []string{string(models.DeliveryPending), string(models.DeliveryDelivered), string(models.DeliveryDead)}
+	generator.component("Webhook", models.Webhook{})
+	generator.component("WebhookDelivery", models.WebhookDelivery{})
+	generator.components["WebhookRequest"] = Schema{
+		"type": "object", "required": []string{"url", "secret"},
+		"properties": Schema{
+			"url":    Schema{"type": "string", "format": "uri", "description": "http or https URL receiving the events."},
+			"events": Schema{"type": "array", "items": ref("PlanetEventType"), "description": "Event types to deliver, all of them when empty."},
+			"secret": Schema{"type": "string", "minLength": 16, "description": "Key of the HMAC-SHA256 signatures, never returned."},
+		},
+	}
+	generator.components["PlanetEventType"] = generator.schemaFor(reflect.TypeOf(models.PlanetEventType("")))
+	generator.components["DeliveryStatus"] = generator.schemaFor(reflect.TypeOf(models.DeliveryStatus("")))
+	generator.component("FilterParam", queryoperations.FilterParam{})
+	generator.component("Problem", problems.Problem{})
+
+	document := Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:       "Go Space Voyagers",
+			Description: "Catalogue of exoplanets and fuel cost estimations for trips to them.",
+			Version:     Version,
+		},
+		Tags: []Tag{
+			{Name: "planets", Description: "Planet catalogue"},
+			{Name: "stars", Description: "Host stars and star systems"},
+			{Name: "planet types", Description: "Registry of planet types, their ranges and gravity models"},
+			{Name: "fuel", Description: "Fuel cost estimations"},
+			{Name: "charts", Description: "Fuel cost and catalogue charts as SVG or PNG images"},
+			{Name: "operations", Description: "Health, readiness, metrics and documentation"},
+			{Name: "webhooks", Description: "Webhook subscriptions to planet changes. These endpoints are not authenticated and deliveries include the event payloads, expose them only to trusted callers."},
+			{Name: "graphql", Description: "GraphQL endpoint for the planet catalogue"},
+		},
+		Paths: map[string]map[string]Operation{},
+		Components: Components{
+			Schemas: generator.components,
+			Responses: map[string]Response{
+				"Problem": {
+					Description: "RFC 7807 problem details describing why the request failed.",
+					Content:     map[string]MediaType{problems.ContentType: {Schema: ref("Problem")}},
+				},
+			},
+		},
+	}
+
+	for _, route := range routes() {
+		path := openAPIPath(route.Path)
+		if document.Paths[path] == nil {
+			document.Paths[path] = map[string]Operation{}
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = route.Operation
+	}
+	return document
+}
+
+// Routes lists the method and gin path of every documented operation, sorted.
+func Routes() []Route {
+	var documented []Route
+	for _, route := range routes() {
+		documented = append(documented, route.Route)
+	}
+	sort.Slice(documented, func(i, j int) bool {
+		if documented[i].Path == documented[j].Path {
+			return documented[i].Method < documented[j].Method
+		}
+		return documented[i].Path < documented[j].Path
+	})
+	return documented
+}
+
+// openAPIPath converts gin path parameters (":id") to OpenAPI templates ("{id}").
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func jsonContent(schema Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// problem references the shared problem details response.
+var problem = Response{Ref: "#/components/responses/Problem"}
+
+// envelope wraps schema in the {"status": ..., key: ...} body used by successful responses.
+func envelope(key string, schema Schema, extra Schema) Schema {
+	properties := Schema{"status": Schema{"type": "integer"}, key: schema}
+	for name, property := range extra {
+		properties[name] = property
+	}
+	return Schema{"type": "object", "properties": properties}
+}
+
+func messageResponse(description string) Response {
+	return Response{Description: description, Content: jsonContent(Schema{
+		"type":       "object",
+		"properties": Schema{"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}},
+	})}
+}
+
+func idParameter(entity string) Parameter {
+	return Parameter{Name: "id", In: "path", Required: true, Description: "Id of the " + entity + ".", Schema: Schema{"type": "integer", "minimum": 1}}
+}
+
+// listParameters documents the sort, pagination and filter[...] syntax of queryoperations.BindQuery.
+func listParameters(filters map[string]string) []Parameter {
+	parameters := []Parameter{
+		{Name: "sort", In: "query", Description: `Comma separated "field [asc|desc]" terms on filterable fields, e.g. "radius desc,name".`, Schema: Schema{"type": "string"}},
+		{Name: "page", In: "query", Description: "1-based page number, only applied together with limit.", Schema: Schema{"type": "integer", "minimum": 1}},
+		{Name: "limit", In: "query", Description: "Page size, only applied together with page.", Schema: Schema{"type": "integer", "minimum": 1}},
+	}
+	return append(parameters, filterParameters(filters)...)
+}
+
+// filterParameters documents the filter[...] syntax of queryoperations.BindQuery.
+func filterParameters(filters map[string]string) []Parameter {
+	fields := make([]string, 0, len(filters))
+	for field := range filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var parameters []Parameter
+	for _, field := range fields {
+		parameters = append(parameters, Parameter{
+			Name: "filter[" + field + "]",
+			In:   "query",
+			Description: "JSON encoded conditions on the " + filters[field] + ` field ` + field + `, e.g. {"gte": 10, "lt": 100}. "like" only applies to string fields. ` +
+				`Operands on distance, radius and mass may carry a unit, e.g. {"gte": "3 pc"}.`,
+			Content: jsonContent(ref("FilterParam")),
+		})
+	}
+	return parameters
+}
+
+// includeParameter asks for the derived properties of planets.
+var includeParameter = Parameter{Name: "include", In: "query", Description: "derived adds the derived physical properties of each planet.", Schema: Schema{"type": "string", "enum": []string{"derived"}}}
+
+// planetTypeParameter is the name of a planet type in the path.
+var planetTypeParameter = Parameter{Name: "name", In: "path", Required: true, Description: "Name of the planet type, such as gas_giant.", Schema: Schema{"type": "string", "pattern": "^[a-z][a-z0-9_]{0,31}$"}}
+
+// unitsParameter converts planet measurements in responses.
+var unitsParameter = Parameter{Name: "units", In: "query", Description: `Comma separated field:unit pairs converting distance, radius, mass and semiMajorAxis, e.g. "distance:pc,mass:Mj". ` +
+	"Units are ly, pc, au and km for distance and semiMajorAxis, Re, Rj and km for radius, and Me and Mj for mass. The response then lists the unit of every measurement under units.", Schema: Schema{"type": "string"}}
+
+// chartParameters are the format of a chart image followed by the given parameters.
+func chartParameters(parameters ...Parameter) []Parameter {
+	format := Parameter{Name: "format", In: "query", Description: "Image format, defaults to svg.", Schema: Schema{"type": "string", "enum": []string{"svg", "png"}}}
+	return append([]Parameter{format}, parameters...)
+}
+
+// chartResponse is a chart image in the requested format.
+func chartResponse(description string) Response {
+	return Response{Description: description, Content: map[string]MediaType{
+		"image/svg+xml": {Schema: Schema{"type": "string"}},
+		"image/png":     {Schema: Schema{"type": "string", "format": "binary"}},
+	}}
+}
+
+// numericFields lists the int and float fields of filters.
+func numericFields(filters map[string]string) []string {
+	var fields []string
+	for field, dataType := range filters {
+		if dataType == "int" || dataType == "float" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func routes() []route {
+	return []route{
+		{Route{"GET", "/healthz"}, Operation{
+			OperationID: "healthz", Summary: "Reports that the process is alive", Tags: []string{"operations"},
+			Responses: map[string]Response{"200": messageResponse("The process is alive.")},
+		}},
+		{Route{"GET", "/readyz"}, Operation{
+			OperationID: "readyz", Summary: "Reports whether the database is reachable and migrations are current", Tags: []string{"operations"},
+			Responses: map[string]Response{"200": messageResponse("Ready to serve traffic."), "503": problem},
+		}},
+		{Route{"GET", "/metrics"}, Operation{
+			OperationID: "metrics", Summary: "Prometheus metrics", Tags: []string{"operations"},
+			Responses: map[string]Response{"200": {Description: "Metrics in the Prometheus text exposition format.", Content: map[string]MediaType{"text/plain": {Schema: Schema{"type": "string"}}}}},
+		}},
+		{Route{"GET", "/openapi.json"}, Operation{
+			OperationID: "openapi", Summary: "This OpenAPI document", Tags: []string{"operations"},
+			Responses: map[string]Response{"200": {Description: "OpenAPI 3.1 document.", Content: jsonContent(Schema{"type": "object"})}},
+		}},
+		{Route{"GET", "/planets"}, Operation{
+			OperationID: "listPlanets", Summary: "Retrieves all the planets", Tags: []string{"planets"},
+			Description: "With crew, every planet carries the fuel cost of a trip for that crew, which can be filtered and sorted on as fuel_cost. " +
+				"The derived properties can be filtered and sorted on whether or not they are included.",
+			Parameters: append([]Parameter{
+				{Name: "crew", In: "query", Description: "Crew capacity to price the planets for.", Schema: Schema{"type": "integer", "minimum": 1}},
+				includeParameter,
+				unitsParameter,
+			}, listParameters(models.PricedPlanetFilters)...),
+			Responses: map[string]Response{
+				"200": {Description: "Planets matching the filters, with fuel_cost when crew is given.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": Schema{
+					"oneOf": []Schema{ref("Planet"), ref("PricedPlanet")},
+				}}, planetPageProperties))},
+				"400": problem,
+				"500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/events"}, Operation{
+			OperationID: "planetEvents", Summary: "Streams planet changes", Tags: []string{"planets"},
+			Description: "Sends created, updated and deleted events of planets matching the filters as Server-Sent Events, " +
+				"or as WebSocket text messages when the request asks for an upgrade. Without a last event id only later changes are sent.",
+			Parameters: append([]Parameter{
+				{Name: "Last-Event-ID", In: "header", Description: "Resume after this event, set by reconnecting EventSource clients.", Schema: Schema{"type": "integer", "minimum": 0}},
+				{Name: "lastEventId", In: "query", Description: "Resume after this event when the header cannot be set.", Schema: Schema{"type": "integer", "minimum": 0}},
+			}, filterParameters(models.EventPlanetFilters)...),
+			Responses: map[string]Response{
+				"101": {Description: "Switched to the WebSocket protocol, each message is a PlanetEvent."},
+				"200": {Description: "Event stream, the data of each event is a PlanetEvent.", Content: map[string]MediaType{"text/event-stream": {Schema: ref("PlanetEvent")}}},
+				"400": problem,
+				"500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/reachable"}, Operation{
+			OperationID: "listReachablePlanets", Summary: "Retrieves the planets a crew can reach within a fuel budget", Tags: []string{"fuel"},
+			Description: "Planets matching the filters whose fuel cost is within the budget, cheapest first. The sort parameter orders planets of equal cost.",
+			Parameters: append([]Parameter{
+				{Name: "crew", In: "query", Required: true, Description: "Crew capacity.", Schema: Schema{"type": "integer", "minimum": 1}},
+				{Name: "budget", In: "query", Required: true, Description: "Highest acceptable fuel cost.", Schema: Schema{"type": "number", "minimum": 0}},
+				includeParameter,
+				unitsParameter,
+			}, listParameters(models.DerivedPlanetFilters)...),
+			Responses: map[string]Response{
+				"200": {Description: "Reachable planets with their fuel cost.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("PricedPlanet")}, planetPageProperties))},
+				"400": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"POST", "/planets"}, Operation{
+			OperationID: "createPlanet", Summary: "Creates a new planet", Tags: []string{"planets"},
+			Description: "Distance must be within (10, 1000) light years, radius and mass within the ranges of the planet type in Earth radii and Earth masses, see /planet-types. " +
+				"Gas giants keep the mass they are sent with, although their fuel cost does not use it. " +
+				`Measurements and their errors may also be sent as strings with a unit, such as "1.1 Rj", distances must convert to whole light years.`,
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("Planet"))},
+			Responses: map[string]Response{
+				"201": {Description: "The created planet.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "planet": ref("Planet"),
+				}})},
+				"400": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/:id"}, Operation{
+			OperationID: "getPlanet", Summary: "Retrieves a planet by its ID", Tags: []string{"planets"},
+			Parameters: []Parameter{idParameter("planet"), includeParameter, unitsParameter},
+			Responses: map[string]Response{
+				"200": {Description: "The planet.", Content: jsonContent(envelope("data", ref("Planet"), nil))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"PUT", "/planets/:id"}, Operation{
+			OperationID: "updatePlanet", Summary: "Updates a planet by its ID", Tags: []string{"planets"},
+			Parameters:  []Parameter{idParameter("planet")},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("Planet"))},
+			Responses: map[string]Response{
+				"200": messageResponse("The planet was updated."),
+				"400": problem, "404": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"DELETE", "/planets/:id"}, Operation{
+			OperationID: "deletePlanet", Summary: "Deletes a planet by its ID", Tags: []string{"planets"},
+			Parameters: []Parameter{idParameter("planet")},
+			Responses: map[string]Response{
+				"200": messageResponse("The planet was deleted."),
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/getFuelCost/:id"}, Operation{
+			OperationID: "getFuelCost", Summary: "Retrieves a planet fuel cost by its ID and crew capacity", Tags: []string{"fuel"},
+			Description: "The crew capacity is sent as a JSON body even though the method is GET. " +
+				"With mode=montecarlo the measurement errors of the planet are also propagated to an estimate by seeded sampling.",
+			Parameters: []Parameter{
+				idParameter("planet"),
+				{Name: "mode", In: "query", Description: "montecarlo adds a sampled estimate, defaults to exact.", Schema: Schema{"type": "string", "enum": []string{"exact", "montecarlo"}}},
+				{Name: "samples", In: "query", Description: "Monte Carlo samples, defaults to 10000.", Schema: Schema{"type": "integer", "minimum": 1, "maximum": 100000}},
+				{Name: "seed", In: "query", Description: "Monte Carlo seed, equal seeds give equal estimates. Defaults to 1.", Schema: Schema{"type": "integer"}},
+				{Name: "percentiles", In: "query", Description: `Comma separated percentiles in [0, 100] of the estimate, defaults to "5,50,95".`, Schema: Schema{"type": "string"}},
+			},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(crewBody)},
+			Responses: map[string]Response{
+				"200": {Description: "Estimated fuel cost, with the terms of the formula distance / gravity^2 * crew.", Content: jsonContent(envelope("data", Schema{"type": "number"}, Schema{
+					"breakdown": ref("FuelCostBreakdown"),
+					"estimate":  ref("FuelCostEstimate"),
+				}))},
+				"400": problem, "404": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/:id/moons"}, Operation{
+			OperationID: "listMoons", Summary: "Retrieves the moons of a planet", Tags: []string{"planets"},
+			Parameters: append([]Parameter{idParameter("planet")}, listParameters(models.MoonFilters)...),
+			Responses: map[string]Response{
+				"200": {Description: "Moons of the planet matching the filters.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("Moon")}, pageProperties))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"POST", "/planets/:id/moons"}, Operation{
+			OperationID: "createMoon", Summary: "Creates a new moon of a planet", Tags: []string{"planets"},
+			Description: "The moon must be smaller and lighter than the planet, and orbit farther than one planet radius. Names are unique per planet.",
+			Parameters:  []Parameter{idParameter("planet")},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("Moon"))},
+			Responses: map[string]Response{
+				"201": {Description: "The created moon.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "moon": ref("Moon"),
+				}})},
+				"400": problem, "404": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/:id/moons/:moonId"}, Operation{
+			OperationID: "getMoon", Summary: "Retrieves a moon of a planet by its ID", Tags: []string{"planets"},
+			Parameters: []Parameter{idParameter("planet"), moonIdParameter},
+			Responses: map[string]Response{
+				"200": {Description: "The moon.", Content: jsonContent(envelope("data", ref("Moon"), nil))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"PUT", "/planets/:id/moons/:moonId"}, Operation{
+			OperationID: "updateMoon", Summary: "Updates a moon of a planet by its ID", Tags: []string{"planets"},
+			Parameters:  []Parameter{idParameter("planet"), moonIdParameter},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("Moon"))},
+			Responses: map[string]Response{
+				"200": messageResponse("The moon was updated."),
+				"400": problem, "404": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"DELETE", "/planets/:id/moons/:moonId"}, Operation{
+			OperationID: "deleteMoon", Summary: "Deletes a moon of a planet by its ID", Tags: []string{"planets"},
+			Parameters: []Parameter{idParameter("planet"), moonIdParameter},
+			Responses: map[string]Response{
+				"200": messageResponse("The moon was deleted."),
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/:id/moons/:moonId/fuelCost"}, Operation{
+			OperationID: "getMoonFuelCost", Summary: "Retrieves the fuel cost to a moon by crew capacity", Tags: []string{"fuel"},
+			Description: "The fuel cost to the planet plus the descent from the planet to the moon. The crew capacity is sent as a JSON body even though the method is GET.",
+			Parameters:  []Parameter{idParameter("planet"), moonIdParameter},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(crewBody)},
+			Responses: map[string]Response{
+				"200": {Description: "Estimated fuel cost.", Content: jsonContent(envelope("data", Schema{"type": "number"}, nil))},
+				"400": problem, "404": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planet-types"}, Operation{
+			OperationID: "listPlanetTypes", Summary: "Retrieves all the planet types", Tags: []string{"planet types"},
+			Description: "Built-in types come first, in their declared order, then the stored types by name.",
+			Responses: map[string]Response{
+				"200": {Description: "Every planet type.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("PlanetTypeDefinition")}, Schema{"total": Schema{"type": "integer"}}))},
+				"500": problem,
+			},
+		}},
+		{Route{"POST", "/planet-types"}, Operation{
+			OperationID: "createPlanetType", Summary: "Creates a new planet type", Tags: []string{"planet types"},
+			Description: "Ranges are exclusive, in Earth radii and Earth masses. The mass gravity model uses mass / radius^2 in the fuel cost, " +
+				"fixed_mass uses gravityMass / radius^2 instead. Planets may use the type as soon as it is created.",
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("PlanetTypeDefinition"))},
+			Responses: map[string]Response{
+				"201": {Description: "The created planet type.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "planetType": ref("PlanetTypeDefinition"),
+				}})},
+				"400": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planet-types/:name"}, Operation{
+			OperationID: "getPlanetType", Summary: "Retrieves a planet type by its name", Tags: []string{"planet types"},
+			Parameters: []Parameter{planetTypeParameter},
+			Responses: map[string]Response{
+				"200": {Description: "The planet type.", Content: jsonContent(envelope("data", ref("PlanetTypeDefinition"), nil))},
+				"404": problem, "500": problem,
+			},
+		}},
+		{Route{"PUT", "/planet-types/:name"}, Operation{
+			OperationID: "updatePlanetType", Summary: "Replaces a stored planet type", Tags: []string{"planet types"},
+			Description: "Built-in types cannot be changed. The name is taken from the path, planets already stored are validated against the new ranges on their next update.",
+			Parameters:  []Parameter{planetTypeParameter},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("PlanetTypeDefinition"))},
+			Responses: map[string]Response{
+				"200": {Description: "The updated planet type.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "planetType": ref("PlanetTypeDefinition"),
+				}})},
+				"400": problem, "404": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"DELETE", "/planet-types/:name"}, Operation{
+			OperationID: "deletePlanetType", Summary: "Deletes a stored planet type", Tags: []string{"planet types"},
+			Description: "Built-in types and types still used by planets cannot be deleted.",
+			Parameters:  []Parameter{planetTypeParameter},
+			Responses: map[string]Response{
+				"200": messageResponse("The planet type was deleted."),
+				"404": problem, "409": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/fuel-matrix"}, Operation{
+			OperationID: "fuelMatrix", Summary: "Streams the fuel cost of the planets for a range of crew sizes", Tags: []string{"fuel"},
+			Description: "Planets are selected with the filters, sorting and pagination of GET /planets. " +
+				"The CSV has a planet_id,name,crew_<size>... header, the text table right aligned columns of at least 14 characters.",
+			Parameters: append([]Parameter{
+				{Name: "crew", In: "query", Description: `Crew size ("5") or inclusive range of sizes ("1..20"), at most 1000 sizes. Defaults to 1.`, Schema: Schema{"type": "string", "pattern": `^[0-9]+(\.\.[0-9]+)?$`}},
+				{Name: "step", In: "query", Description: "Increment between crew sizes of a range, defaults to 1.", Schema: Schema{"type": "integer", "minimum": 1}},
+				{Name: "format", In: "query", Description: "Output format, defaults to json.", Schema: Schema{"type": "string", "enum": []string{"json", "csv", "table"}}},
+			}, listParameters(models.DerivedPlanetFilters)...),
+			Responses: map[string]Response{
+				"200": {Description: "Fuel cost of every planet for every crew size.", Content: map[string]MediaType{
+					"application/json": {Schema: Schema{"type": "object", "properties": Schema{
+						"status": Schema{"type": "integer"},
+						"crew":   Schema{"type": "array", "items": Schema{"type": "integer"}},
+						"data": Schema{"type": "array", "items": Schema{"type": "object", "properties": Schema{
+							"planetId":  Schema{"type": "integer"},
+							"name":      Schema{"type": "string"},
+							"fuelCosts": Schema{"type": "array", "items": Schema{"type": "number"}, "description": "One cost per crew size, in the order of crew."},
+						}}},
+					}}},
+					"text/csv":   {Schema: Schema{"type": "string"}},
+					"text/plain": {Schema: Schema{"type": "string"}},
+				}},
+				"400": problem, "500": problem,
+			},
+		}},
+		{Route{"POST", "/itineraries/plan"}, Operation{
+			OperationID: "planItinerary", Summary: "Plans the cheapest order to visit several planets", Tags: []string{"fuel"},
+			Description: "Starts from Earth without returning. Each leg costs the planet fuel cost over the distance from the previous stop. " +
+				"Itineraries of up to 12 planets are solved exactly, larger ones with a 2-opt heuristic.",
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(Schema{
+				"type": "object", "required": []string{"planetIds", "crew"},
+				"properties": Schema{
+					"planetIds":      Schema{"type": "array", "items": Schema{"type": "integer"}, "minItems": 1, "maxItems": 100, "description": "Planets to visit, each once."},
+					"crew":           Schema{"type": "integer", "minimum": 1},
+					"mustVisitOrder": Schema{"type": "array", "items": Schema{"type": "integer"}, "description": "Planets of planetIds to visit in this relative order."},
+					"maxStops":       Schema{"type": "integer", "minimum": 0, "description": "Rejects itineraries with more planets when positive."},
+				},
+			})},
+			Responses: map[string]Response{
+				"200": {Description: "The planned itinerary.", Content: jsonContent(envelope("data", ref("Itinerary"), nil))},
+				"400": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/planets/:id/charts/fuel-cost"}, Operation{
+			OperationID: "fuelCostChart", Summary: "Draws the fuel cost of a trip to a planet against the crew size", Tags: []string{"charts"},
+			Parameters: chartParameters(
+				idParameter("planet"),
+				Parameter{Name: "crew", In: "query", Description: `Crew size ("5") or inclusive range of sizes ("1..20"), at most 1000 sizes. Defaults to 1..20.`, Schema: Schema{"type": "string", "pattern": `^[0-9]+(\.\.[0-9]+)?$`}},
+				Parameter{Name: "step", In: "query", Description: "Increment between crew sizes of a range, defaults to 1.", Schema: Schema{"type": "integer", "minimum": 1}},
+			),
+			Responses: map[string]Response{
+				"200": chartResponse("A line chart of the fuel cost per crew size."),
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/charts/distance-radius"}, Operation{
+			OperationID: "distanceRadiusChart", Summary: "Plots the distance against the radius of the planets", Tags: []string{"charts"},
+			Description: "Planets are selected with the filters of GET /planets, pagination is ignored.",
+			Parameters:  chartParameters(filterParameters(models.DerivedPlanetFilters)...),
+			Responses: map[string]Response{
+				"200": chartResponse("A scatter chart with a colour per planet type."),
+				"400": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/charts/histogram"}, Operation{
+			OperationID: "histogramChart", Summary: "Counts the planets in bins of a numeric field", Tags: []string{"charts"},
+			Description: "Planets are selected with the filters of GET /planets, pagination is ignored. Planets without a value, such as star fields of planets without a star, are left out.",
+			Parameters: chartParameters(append([]Parameter{
+				{Name: "field", In: "query", Required: true, Description: "Numeric field to count the planets by.", Schema: Schema{"type": "string", "enum": numericFields(models.DerivedPlanetFilters)}},
+				{Name: "bins", In: "query", Description: "Number of bins of equal width, defaults to 10.", Schema: Schema{"type": "integer", "minimum": 1, "maximum": 100}},
+			}, filterParameters(models.DerivedPlanetFilters)...)...),
+			Responses: map[string]Response{
+				"200": chartResponse("A histogram of the field."),
+				"400": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/stars"}, Operation{
+			OperationID: "listStars", Summary: "Retrieves all the stars", Tags: []string{"stars"},
+			Parameters: listParameters(models.StarFilters),
+			Responses: map[string]Response{
+				"200": {Description: "Stars matching the filters.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("Star")}, pageProperties))},
+				"400": problem,
+				"500": problem,
+			},
+		}},
+		{Route{"POST", "/stars"}, Operation{
+			OperationID: "createStar", Summary: "Creates a new star", Tags: []string{"stars"},
+			Description: "Mass must be within (0, 300] solar masses, luminosity positive and distance not negative. Names are unique.",
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("Star"))},
+			Responses: map[string]Response{
+				"201": {Description: "The created star.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "star": ref("Star"),
+				}})},
+				"400": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/stars/:id"}, Operation{
+			OperationID: "getStar", Summary: "Retrieves a star by its ID", Tags: []string{"stars"},
+			Parameters: []Parameter{idParameter("star")},
+			Responses: map[string]Response{
+				"200": {Description: "The star.", Content: jsonContent(envelope("data", ref("Star"), nil))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/stars/:id/planets"}, Operation{
+			OperationID: "listStarPlanets", Summary: "Retrieves the planets hosted by a star", Tags: []string{"stars"},
+			Parameters: append([]Parameter{idParameter("star"), includeParameter, unitsParameter}, listParameters(models.DerivedPlanetFilters)...),
+			Responses: map[string]Response{
+				"200": {Description: "Planets of the star matching the filters.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("Planet")}, pageProperties))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"PUT", "/stars/:id"}, Operation{
+			OperationID: "updateStar", Summary: "Updates a star by its ID", Tags: []string{"stars"},
+			Parameters:  []Parameter{idParameter("star")},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("Star"))},
+			Responses: map[string]Response{
+				"200": messageResponse("The star was updated."),
+				"400": problem, "404": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"DELETE", "/stars/:id"}, Operation{
+			OperationID: "deleteStar", Summary: "Deletes a star by its ID", Tags: []string{"stars"},
+			Description: "A star still hosting planets is only deleted with cascade=true, which deletes its planets too.",
+			Parameters: []Parameter{
+				idParameter("star"),
+				{Name: "cascade", In: "query", Description: "Also delete the planets of the star.", Schema: Schema{"type": "boolean"}},
+			},
+			Responses: map[string]Response{
+				"200": messageResponse("The star was deleted."),
+				"400": problem, "404": problem, "409": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/star-systems"}, Operation{
+			OperationID: "listStarSystems", Summary: "Retrieves all the star systems", Tags: []string{"stars"},
+			Parameters: listParameters(models.StarSystemFilters),
+			Responses: map[string]Response{
+				"200": {Description: "Star systems matching the filters.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("StarSystem")}, pageProperties))},
+				"400": problem,
+				"500": problem,
+			},
+		}},
+		{Route{"POST", "/star-systems"}, Operation{
+			OperationID: "createStarSystem", Summary: "Creates a new star system", Tags: []string{"stars"},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("StarSystem"))},
+			Responses: map[string]Response{
+				"201": {Description: "The created star system.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "starSystem": ref("StarSystem"),
+				}})},
+				"400": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/star-systems/:id"}, Operation{
+			OperationID: "getStarSystem", Summary: "Retrieves a star system by its ID", Tags: []string{"stars"},
+			Parameters: []Parameter{idParameter("star system")},
+			Responses: map[string]Response{
+				"200": {Description: "The star system.", Content: jsonContent(envelope("data", ref("StarSystem"), nil))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/star-systems/:id/stars"}, Operation{
+			OperationID: "listStarSystemStars", Summary: "Retrieves the stars of a star system", Tags: []string{"stars"},
+			Parameters: append([]Parameter{idParameter("star system")}, listParameters(models.StarFilters)...),
+			Responses: map[string]Response{
+				"200": {Description: "Stars of the system matching the filters.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("Star")}, pageProperties))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"PUT", "/star-systems/:id"}, Operation{
+			OperationID: "updateStarSystem", Summary: "Updates a star system by its ID", Tags: []string{"stars"},
+			Parameters:  []Parameter{idParameter("star system")},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("StarSystem"))},
+			Responses: map[string]Response{
+				"200": messageResponse("The star system was updated."),
+				"400": problem, "404": problem, "409": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"DELETE", "/star-systems/:id"}, Operation{
+			OperationID: "deleteStarSystem", Summary: "Deletes a star system by its ID", Tags: []string{"stars"},
+			Description: "The stars of the system are kept without a system.",
+			Parameters:  []Parameter{idParameter("star system")},
+			Responses: map[string]Response{
+				"200": messageResponse("The star system was deleted."),
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/webhooks"}, Operation{
+			OperationID: "listWebhooks", Summary: "Retrieves all the webhook subscriptions", Tags: []string{"webhooks"},
+			Responses: map[string]Response{
+				"200": {Description: "Webhook subscriptions.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("Webhook")}, nil))},
+				"500": problem,
+			},
+		}},
+		{Route{"POST", "/webhooks"}, Operation{
+			OperationID: "createWebhook", Summary: "Subscribes a URL to planet events", Tags: []string{"webhooks"},
+			Description: "Deliveries are POSTed with the X-Voyagers-Event, X-Voyagers-Delivery, X-Voyagers-Timestamp and X-Voyagers-Signature headers. " +
+				`The signature is "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. ` +
+				"Deliveries only reach public addresses, unless the server allows other networks with WEBHOOK_ALLOWED_NETWORKS.",
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("WebhookRequest"))},
+			Responses: map[string]Response{
+				"201": {Description: "The created webhook.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "webhook": ref("Webhook"),
+				}})},
+				"400": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/webhooks/:id"}, Operation{
+			OperationID: "getWebhook", Summary: "Retrieves a webhook subscription by its ID", Tags: []string{"webhooks"},
+			Parameters: []Parameter{idParameter("webhook")},
+			Responses: map[string]Response{
+				"200": {Description: "The webhook.", Content: jsonContent(envelope("data", ref("Webhook"), nil))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"PUT", "/webhooks/:id"}, Operation{
+			OperationID: "updateWebhook", Summary: "Updates a webhook subscription by its ID", Tags: []string{"webhooks"},
+			Parameters:  []Parameter{idParameter("webhook")},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(ref("WebhookRequest"))},
+			Responses: map[string]Response{
+				"200": messageResponse("The webhook was updated."),
+				"400": problem, "404": problem, "422": problem, "500": problem,
+			},
+		}},
+		{Route{"DELETE", "/webhooks/:id"}, Operation{
+			OperationID: "deleteWebhook", Summary: "Deletes a webhook subscription and its deliveries", Tags: []string{"webhooks"},
+			Parameters: []Parameter{idParameter("webhook")},
+			Responses: map[string]Response{
+				"200": messageResponse("The webhook was deleted."),
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/webhooks/:id/deliveries"}, Operation{
+			OperationID: "listWebhookDeliveries", Summary: "Retrieves the deliveries of a webhook, newest first", Tags: []string{"webhooks"},
+			Description: "Deliveries that ran out of attempts have the dead status, list them with ?status=dead.",
+			Parameters: []Parameter{
+				idParameter("webhook"),
+				{Name: "status", In: "query", Description: "Only deliveries with this status.", Schema: ref("DeliveryStatus")},
+			},
+			Responses: map[string]Response{
+				"200": {Description: "Deliveries of the webhook.", Content: jsonContent(envelope("data", Schema{"type": "array", "items": ref("WebhookDelivery")}, nil))},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"POST", "/webhooks/:id/deliveries/:deliveryId/redeliver"}, Operation{
+			OperationID: "redeliverWebhook", Summary: "Queues a delivery for another attempt", Tags: []string{"webhooks"},
+			Description: "The delivery gets a fresh retry budget, whether it was delivered or dead.",
+			Parameters: []Parameter{
+				idParameter("webhook"),
+				{Name: "deliveryId", In: "path", Required: true, Description: "Id of the delivery.", Schema: Schema{"type": "integer", "minimum": 1}},
+			},
+			Responses: map[string]Response{
+				"202": {Description: "The queued delivery.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+					"status": Schema{"type": "integer"}, "message": Schema{"type": "string"}, "data": ref("WebhookDelivery"),
+				}})},
+				"400": problem, "404": problem, "500": problem,
+			},
+		}},
+		{Route{"GET", "/graphql"}, Operation{
+			OperationID: "graphqlQuery", Summary: "Runs a GraphQL query", Tags: []string{"graphql"},
+			Description: "Mutations are rejected, send them with POST.",
+			Parameters: []Parameter{
+				{Name: "query", In: "query", Required: true, Description: "GraphQL document.", Schema: Schema{"type": "string"}},
+				{Name: "operationName", In: "query", Description: "Operation to run when the document has several.", Schema: Schema{"type": "string"}},
+				{Name: "variables", In: "query", Description: "JSON object of variable values.", Schema: Schema{"type": "string"}},
+			},
+			Responses: map[string]Response{"200": graphqlResponse, "400": problem, "405": problem},
+		}},
+		{Route{"POST", "/graphql"}, Operation{
+			OperationID: "graphqlExecute", Summary: "Runs a GraphQL query or mutation", Tags: []string{"graphql"},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(Schema{
+				"type": "object", "required": []string{"query"},
+				"properties": Schema{
+					"query":         Schema{"type": "string"},
+					"operationName": Schema{"type": "string"},
+					"variables":     Schema{"type": "object"},
+				},
+			})},
+			Responses: map[string]Response{"200": graphqlResponse, "400": problem},
+		}},
+	}
+}
+
+// crewBody is the JSON body of fuel cost requests.
+var crewBody = Schema{
+	"type": "object", "required": []string{"Capacity"},
+	"properties": Schema{"Capacity": Schema{"type": "integer", "minimum": 1}},
+}
+
+var moonIdParameter = Parameter{Name: "moonId", In: "path", Required: true, Description: "Id of the moon.", Schema: Schema{"type": "integer", "minimum": 1}}
+
+// pageProperties are the pagination fields of list responses next to their data.
+var pageProperties = Schema{
+	"total": Schema{"type": "integer", "description": "Number of items in this page."},
+	"page":  Schema{"type": "integer"},
+	"limit": Schema{"type": "integer"},
+}
+
+// planetPageProperties are the pagination fields of planet listings, their total counts every page.
+var planetPageProperties = Schema{
+	"total": Schema{"type": "integer", "description": "Number of planets matching the filters over all pages."},
+	"page":  Schema{"type": "integer"},
+	"limit": Schema{"type": "integer"},
+}
+
+// graphqlResponse is the standard GraphQL result, errors carry the problem code and status in their extensions.
+var graphqlResponse = Response{Description: "GraphQL result.", Content: jsonContent(Schema{"type": "object", "properties": Schema{
+	"data":   Schema{"type": "object"},
+	"errors": Schema{"type": "array", "items": Schema{"type": "object"}},
+}})}
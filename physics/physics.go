@@ -0,0 +1,42 @@
+// Package physics derives physical properties of planets from their catalogue measurements.
+// Masses are in Earth masses, radii in Earth radii, luminosities in solar luminosities and orbits in astronomical units.
+package physics
+
+import "math"
+
+// SI values of the constants and units used by the derivations.
+const (
+	G               = 6.6743e-11     // m^3 kg^-1 s^-2
+	StefanBoltzmann = 5.670374419e-8 // W m^-2 K^-4
+	EarthMass       = 5.9722e24      // kg
+	EarthRadius     = 6.371e6        // m
+	SolarLuminosity = 3.828e26       // W
+	AU              = 1.495978707e11 // m
+)
+
+// BondAlbedo is the fraction of starlight reflected by planets in equilibrium temperatures, close to Earth's.
+const BondAlbedo = 0.3
+
+// SurfaceGravity is the gravitational acceleration at the surface, in m/s^2.
+func SurfaceGravity(mass float64, radius float64) float64 {
+	r := radius * EarthRadius
+	return G * mass * EarthMass / (r * r)
+}
+
+// EscapeVelocity is the speed needed to escape from the surface, in km/s.
+func EscapeVelocity(mass float64, radius float64) float64 {
+	return math.Sqrt(2*G*mass*EarthMass/(radius*EarthRadius)) / 1000
+}
+
+// Density is the mean density, in g/cm^3.
+func Density(mass float64, radius float64) float64 {
+	r := radius * EarthRadius
+	return mass * EarthMass / (4.0 / 3.0 * math.Pi * r * r * r) / 1000
+}
+
+// EquilibriumTemperature is the temperature of a planet orbiting a star at semiMajorAxis that re-radiates the starlight
+// it absorbs evenly over its surface, in kelvin.
+func EquilibriumTemperature(luminosity float64, semiMajorAxis float64) float64 {
+	a := semiMajorAxis * AU
+	return math.Pow(luminosity*SolarLuminosity*(1-BondAlbedo)/(16*math.Pi*StefanBoltzmann*a*a), 0.25)
+}
@@ -0,0 +1,21 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarth(t *testing.T) {
+	assert.InDelta(t, 9.82, SurfaceGravity(1, 1), 0.01)
+	assert.InDelta(t, 11.19, EscapeVelocity(1, 1), 0.01)
+	assert.InDelta(t, 5.51, Density(1, 1), 0.01)
+	assert.InDelta(t, 255, EquilibriumTemperature(1, 1), 1)
+}
+
+func TestJupiter(t *testing.T) {
+	assert.InDelta(t, 24.8, SurfaceGravity(317.8, 11.21), 0.1)
+	assert.InDelta(t, 59.5, EscapeVelocity(317.8, 11.21), 0.1)
+	assert.InDelta(t, 1.24, Density(317.8, 11.21), 0.01)
+	assert.InDelta(t, 111.6, EquilibriumTemperature(1, 5.2), 0.1)
+}
@@ -0,0 +1,114 @@
+package problems
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of RFC 7807 problem details documents.
+const ContentType = "application/problem+json"
+
+// Machine-readable problem codes, stable across releases.
+const (
+	InvalidID        = "invalid_id"
+	InvalidQuery     = "invalid_query"
+	InvalidBody      = "invalid_body"
+	ValidationFailed = "validation_failed"
+	NotFound         = "not_found"
+	MethodNotAllowed = "method_not_allowed"
+	Conflict         = "conflict"
+	Internal         = "internal_error"
+	NotReady         = "not_ready"
+)
+
+// Problem is an RFC 7807 problem details document, extended with a machine-readable code.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return p.Title
+	}
+	return p.Title + ": " + p.Detail
+}
+
+// New builds a problem for the given status and code, with a human-readable detail.
+func New(status int, code string, detail string) *Problem {
+	return &Problem{
+		Type:   "urn:go-space-voyagers:problem:" + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+func BadRequest(code string, detail string) *Problem {
+	return New(http.StatusBadRequest, code, detail)
+}
+
+func Unprocessable(detail string) *Problem {
+	return New(http.StatusUnprocessableEntity, ValidationFailed, detail)
+}
+
+func NotFoundf(format string, args ...any) *Problem {
+	return New(http.StatusNotFound, NotFound, fmt.Sprintf(format, args...))
+}
+
+func Conflictf(format string, args ...any) *Problem {
+	return New(http.StatusConflict, Conflict, fmt.Sprintf(format, args...))
+}
+
+func InternalError(detail string) *Problem {
+	return New(http.StatusInternalServerError, Internal, detail)
+}
+
+// Abort writes err as a problem details response and stops the handler chain.
+// Errors that are not problems are reported as internal errors without leaking their text.
+func Abort(context *gin.Context, err error) {
+	var problem *Problem
+	if !errors.As(err, &problem) {
+		slog.ErrorContext(context.Request.Context(), "unhandled error", slog.String("error", err.Error()))
+		problem = InternalError("An unexpected error occurred. Try again later.")
+	}
+
+	response := *problem
+	if response.Instance == "" {
+		response.Instance = context.Request.URL.Path
+	}
+	_ = context.Error(err)
+	context.Header("Content-Type", ContentType)
+	context.AbortWithStatusJSON(response.Status, response)
+}
+
+// Recovery converts panics in handlers into an internal error problem response.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func (context *gin.Context, recovered any) {
+		slog.ErrorContext(context.Request.Context(), "panic recovered", slog.Any("panic", recovered), slog.String("path", context.Request.URL.Path))
+		Abort(context, InternalError("An unexpected error occurred. Try again later."))
+	})
+}
+
+// NoRoute answers requests for unknown paths with a not found problem.
+func NoRoute() gin.HandlerFunc {
+	return func (context *gin.Context) {
+		Abort(context, NotFoundf("No route matches %s %s.", context.Request.Method, context.Request.URL.Path))
+	}
+}
+
+// NoMethod answers requests with an unsupported method with a method not allowed problem.
+func NoMethod() gin.HandlerFunc {
+	return func (context *gin.Context) {
+		Abort(context, New(http.StatusMethodNotAllowed, MethodNotAllowed, fmt.Sprintf("Method %s is not allowed on %s.", context.Request.Method, context.Request.URL.Path)))
+	}
+}
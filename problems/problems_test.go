@@ -0,0 +1,64 @@
+package problems
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.Use(Recovery())
+	router.NoRoute(NoRoute())
+	router.NoMethod(NoMethod())
+	router.GET("/conflict", func (context *gin.Context) {
+		Abort(context, Conflictf("A planet named %s already exists.", "Jupiter"))
+	})
+	router.GET("/database", func (context *gin.Context) {
+		Abort(context, errors.New("no such table: planets"))
+	})
+	router.GET("/panic", func (context *gin.Context) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		method string
+		endpoint string
+		expectedStatus int
+		expectedCode string
+		expectedDetail string
+	}{
+		{"GET", "/conflict", http.StatusConflict, Conflict, "A planet named Jupiter already exists."},
+		// internal error text must not leak to clients
+		{"GET", "/database", http.StatusInternalServerError, Internal, "An unexpected error occurred. Try again later."},
+		{"GET", "/panic", http.StatusInternalServerError, Internal, "An unexpected error occurred. Try again later."},
+		{"GET", "/missing", http.StatusNotFound, NotFound, "No route matches GET /missing."},
+		{"POST", "/conflict", http.StatusMethodNotAllowed, MethodNotAllowed, "Method POST is not allowed on /conflict."},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(test.method, test.endpoint, nil)
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, test.expectedStatus, w.Code)
+		assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+		var problem Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		assert.Equal(t, test.expectedStatus, problem.Status)
+		assert.Equal(t, test.expectedCode, problem.Code)
+		assert.Equal(t, test.expectedDetail, problem.Detail)
+		assert.Equal(t, "urn:go-space-voyagers:problem:"+test.expectedCode, problem.Type)
+		assert.Equal(t, http.StatusText(test.expectedStatus), problem.Title)
+		assert.Equal(t, test.endpoint, problem.Instance)
+	}
+}
@@ -0,0 +1,1436 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: voyagers/v1/planets.proto
+
+package voyagersv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Planet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Distance    int64   `protobuf:"varint,4,opt,name=distance,proto3" json:"distance,omitempty"`
+	Radius      float64 `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Mass        float64 `protobuf:"fixed64,6,opt,name=mass,proto3" json:"mass,omitempty"`
+	// "gas_giant" or "terrestrial".
+	Type      string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Planet) Reset() {
+	*x = Planet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Planet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Planet) ProtoMessage() {}
+
+func (x *Planet) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Planet.ProtoReflect.Descriptor instead.
+func (*Planet) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Planet) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Planet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Planet) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Planet) GetDistance() int64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *Planet) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *Planet) GetMass() float64 {
+	if x != nil {
+		return x.Mass
+	}
+	return 0
+}
+
+func (x *Planet) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Planet) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Planet) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// FilterParam holds the same conditions as the REST filter[field]={...} syntax.
+type FilterParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Eq    *structpb.Value `protobuf:"bytes,1,opt,name=eq,proto3" json:"eq,omitempty"`
+	Neq   *structpb.Value `protobuf:"bytes,2,opt,name=neq,proto3" json:"neq,omitempty"`
+	Gt    *structpb.Value `protobuf:"bytes,3,opt,name=gt,proto3" json:"gt,omitempty"`
+	Gte   *structpb.Value `protobuf:"bytes,4,opt,name=gte,proto3" json:"gte,omitempty"`
+	Lt    *structpb.Value `protobuf:"bytes,5,opt,name=lt,proto3" json:"lt,omitempty"`
+	Lte   *structpb.Value `protobuf:"bytes,6,opt,name=lte,proto3" json:"lte,omitempty"`
+	Like  string          `protobuf:"bytes,7,opt,name=like,proto3" json:"like,omitempty"`
+	In    []string        `protobuf:"bytes,8,rep,name=in,proto3" json:"in,omitempty"`
+	NotIn []string        `protobuf:"bytes,9,rep,name=not_in,json=notIn,proto3" json:"not_in,omitempty"`
+}
+
+func (x *FilterParam) Reset() {
+	*x = FilterParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterParam) ProtoMessage() {}
+
+func (x *FilterParam) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterParam.ProtoReflect.Descriptor instead.
+func (*FilterParam) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterParam) GetEq() *structpb.Value {
+	if x != nil {
+		return x.Eq
+	}
+	return nil
+}
+
+func (x *FilterParam) GetNeq() *structpb.Value {
+	if x != nil {
+		return x.Neq
+	}
+	return nil
+}
+
+func (x *FilterParam) GetGt() *structpb.Value {
+	if x != nil {
+		return x.Gt
+	}
+	return nil
+}
+
+func (x *FilterParam) GetGte() *structpb.Value {
+	if x != nil {
+		return x.Gte
+	}
+	return nil
+}
+
+func (x *FilterParam) GetLt() *structpb.Value {
+	if x != nil {
+		return x.Lt
+	}
+	return nil
+}
+
+func (x *FilterParam) GetLte() *structpb.Value {
+	if x != nil {
+		return x.Lte
+	}
+	return nil
+}
+
+func (x *FilterParam) GetLike() string {
+	if x != nil {
+		return x.Like
+	}
+	return ""
+}
+
+func (x *FilterParam) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *FilterParam) GetNotIn() []string {
+	if x != nil {
+		return x.NotIn
+	}
+	return nil
+}
+
+type ListPlanetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Comma separated "field [asc|desc]" terms.
+	Sort string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Conditions keyed by field name.
+	Filters map[string]*FilterParam `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Pagination is applied when both page and limit are positive.
+	Page  int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListPlanetsRequest) Reset() {
+	*x = ListPlanetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPlanetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlanetsRequest) ProtoMessage() {}
+
+func (x *ListPlanetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlanetsRequest.ProtoReflect.Descriptor instead.
+func (*ListPlanetsRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPlanetsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListPlanetsRequest) GetFilters() map[string]*FilterParam {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *ListPlanetsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPlanetsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPlanetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Planets []*Planet `protobuf:"bytes,1,rep,name=planets,proto3" json:"planets,omitempty"`
+	Total   int64     `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page    int32     `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit   int32     `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListPlanetsResponse) Reset() {
+	*x = ListPlanetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPlanetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlanetsResponse) ProtoMessage() {}
+
+func (x *ListPlanetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlanetsResponse.ProtoReflect.Descriptor instead.
+func (*ListPlanetsResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPlanetsResponse) GetPlanets() []*Planet {
+	if x != nil {
+		return x.Planets
+	}
+	return nil
+}
+
+func (x *ListPlanetsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPlanetsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPlanetsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type StreamPlanetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sort    string                  `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Filters map[string]*FilterParam `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StreamPlanetsRequest) Reset() {
+	*x = StreamPlanetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamPlanetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPlanetsRequest) ProtoMessage() {}
+
+func (x *StreamPlanetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPlanetsRequest.ProtoReflect.Descriptor instead.
+func (*StreamPlanetsRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamPlanetsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *StreamPlanetsRequest) GetFilters() map[string]*FilterParam {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type StreamPlanetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Planet *Planet `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+}
+
+func (x *StreamPlanetsResponse) Reset() {
+	*x = StreamPlanetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamPlanetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPlanetsResponse) ProtoMessage() {}
+
+func (x *StreamPlanetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPlanetsResponse.ProtoReflect.Descriptor instead.
+func (*StreamPlanetsResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamPlanetsResponse) GetPlanet() *Planet {
+	if x != nil {
+		return x.Planet
+	}
+	return nil
+}
+
+type GetPlanetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetPlanetRequest) Reset() {
+	*x = GetPlanetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPlanetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanetRequest) ProtoMessage() {}
+
+func (x *GetPlanetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanetRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanetRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPlanetRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetPlanetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Planet *Planet `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+}
+
+func (x *GetPlanetResponse) Reset() {
+	*x = GetPlanetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPlanetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanetResponse) ProtoMessage() {}
+
+func (x *GetPlanetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanetResponse.ProtoReflect.Descriptor instead.
+func (*GetPlanetResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPlanetResponse) GetPlanet() *Planet {
+	if x != nil {
+		return x.Planet
+	}
+	return nil
+}
+
+type CreatePlanetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Planet *Planet `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+}
+
+func (x *CreatePlanetRequest) Reset() {
+	*x = CreatePlanetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePlanetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlanetRequest) ProtoMessage() {}
+
+func (x *CreatePlanetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlanetRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlanetRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreatePlanetRequest) GetPlanet() *Planet {
+	if x != nil {
+		return x.Planet
+	}
+	return nil
+}
+
+type CreatePlanetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Planet *Planet `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+}
+
+func (x *CreatePlanetResponse) Reset() {
+	*x = CreatePlanetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePlanetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlanetResponse) ProtoMessage() {}
+
+func (x *CreatePlanetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlanetResponse.ProtoReflect.Descriptor instead.
+func (*CreatePlanetResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreatePlanetResponse) GetPlanet() *Planet {
+	if x != nil {
+		return x.Planet
+	}
+	return nil
+}
+
+type UpdatePlanetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Planet *Planet `protobuf:"bytes,2,opt,name=planet,proto3" json:"planet,omitempty"`
+}
+
+func (x *UpdatePlanetRequest) Reset() {
+	*x = UpdatePlanetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePlanetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlanetRequest) ProtoMessage() {}
+
+func (x *UpdatePlanetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlanetRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePlanetRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdatePlanetRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdatePlanetRequest) GetPlanet() *Planet {
+	if x != nil {
+		return x.Planet
+	}
+	return nil
+}
+
+type UpdatePlanetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Planet *Planet `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+}
+
+func (x *UpdatePlanetResponse) Reset() {
+	*x = UpdatePlanetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePlanetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlanetResponse) ProtoMessage() {}
+
+func (x *UpdatePlanetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlanetResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePlanetResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdatePlanetResponse) GetPlanet() *Planet {
+	if x != nil {
+		return x.Planet
+	}
+	return nil
+}
+
+type DeletePlanetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeletePlanetRequest) Reset() {
+	*x = DeletePlanetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePlanetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePlanetRequest) ProtoMessage() {}
+
+func (x *DeletePlanetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePlanetRequest.ProtoReflect.Descriptor instead.
+func (*DeletePlanetRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeletePlanetRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeletePlanetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeletePlanetResponse) Reset() {
+	*x = DeletePlanetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePlanetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePlanetResponse) ProtoMessage() {}
+
+func (x *DeletePlanetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePlanetResponse.ProtoReflect.Descriptor instead.
+func (*DeletePlanetResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{13}
+}
+
+type GetFuelCostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CrewCapacity int64  `protobuf:"varint,2,opt,name=crew_capacity,json=crewCapacity,proto3" json:"crew_capacity,omitempty"`
+}
+
+func (x *GetFuelCostRequest) Reset() {
+	*x = GetFuelCostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFuelCostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFuelCostRequest) ProtoMessage() {}
+
+func (x *GetFuelCostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFuelCostRequest.ProtoReflect.Descriptor instead.
+func (*GetFuelCostRequest) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetFuelCostRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetFuelCostRequest) GetCrewCapacity() int64 {
+	if x != nil {
+		return x.CrewCapacity
+	}
+	return 0
+}
+
+type GetFuelCostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FuelCost float64 `protobuf:"fixed64,1,opt,name=fuel_cost,json=fuelCost,proto3" json:"fuel_cost,omitempty"`
+}
+
+func (x *GetFuelCostResponse) Reset() {
+	*x = GetFuelCostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_voyagers_v1_planets_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFuelCostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFuelCostResponse) ProtoMessage() {}
+
+func (x *GetFuelCostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voyagers_v1_planets_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFuelCostResponse.ProtoReflect.Descriptor instead.
+func (*GetFuelCostResponse) Descriptor() ([]byte, []int) {
+	return file_voyagers_v1_planets_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetFuelCostResponse) GetFuelCost() float64 {
+	if x != nil {
+		return x.FuelCost
+	}
+	return 0
+}
+
+var File_voyagers_v1_planets_proto protoreflect.FileDescriptor
+
+var file_voyagers_v1_planets_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x76, 0x6f, 0x79,
+	0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x02, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x61, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xbe, 0x02, 0x0a, 0x0b, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x26, 0x0a, 0x02, 0x65, 0x71,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02,
+	0x65, 0x71, 0x12, 0x28, 0x0a, 0x03, 0x6e, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6e, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x02,
+	0x67, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x02, 0x67, 0x74, 0x12, 0x28, 0x0a, 0x03, 0x67, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x67, 0x74, 0x65, 0x12, 0x26,
+	0x0a, 0x02, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x02, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x03, 0x6c, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6c, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6b, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6c, 0x69, 0x6b, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x49, 0x6e, 0x22, 0xf0, 0x01, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0x54, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x6f, 0x79, 0x61,
+	0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x84,
+	0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x07, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xca, 0x01, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f,
+	0x72, 0x74, 0x12, 0x48, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x54, 0x0a, 0x0c,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x44, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f,
+	0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74,
+	0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50,
+	0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x40, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x22, 0x42,
+	0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x74, 0x22, 0x43, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x79,
+	0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52,
+	0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x22, 0x52, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2b,
+	0x0a, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61,
+	0x6e, 0x65, 0x74, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x22, 0x43, 0x0a, 0x14, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x74,
+	0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x49, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x77, 0x5f, 0x63, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x72,
+	0x65, 0x77, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x32, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x46, 0x75, 0x65, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x75, 0x65, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x32, 0xd8,
+	0x04, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x50, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x12,
+	0x1f, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6c, 0x61, 0x6e, 0x65,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x76, 0x6f, 0x79, 0x61,
+	0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x12, 0x20, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61,
+	0x6e, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x76, 0x6f, 0x79,
+	0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
+	0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a,
+	0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x12, 0x20, 0x2e,
+	0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x74, 0x12, 0x20, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x75,
+	0x65, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x43, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x43, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x61, 0x69, 0x74, 0x6f, 0x75, 0x2d, 0x31,
+	0x34, 0x31, 0x32, 0x2f, 0x47, 0x6f, 0x2d, 0x53, 0x70, 0x61, 0x63, 0x65, 0x2d, 0x56, 0x6f, 0x79,
+	0x61, 0x67, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x6f, 0x79, 0x61,
+	0x67, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x6f, 0x79, 0x61, 0x67, 0x65, 0x72, 0x73,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_voyagers_v1_planets_proto_rawDescOnce sync.Once
+	file_voyagers_v1_planets_proto_rawDescData = file_voyagers_v1_planets_proto_rawDesc
+)
+
+func file_voyagers_v1_planets_proto_rawDescGZIP() []byte {
+	file_voyagers_v1_planets_proto_rawDescOnce.Do(func() {
+		file_voyagers_v1_planets_proto_rawDescData = protoimpl.X.CompressGZIP(file_voyagers_v1_planets_proto_rawDescData)
+	})
+	return file_voyagers_v1_planets_proto_rawDescData
+}
+
+var file_voyagers_v1_planets_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_voyagers_v1_planets_proto_goTypes = []any{
+	(*Planet)(nil),                // 0: voyagers.v1.Planet
+	(*FilterParam)(nil),           // 1: voyagers.v1.FilterParam
+	(*ListPlanetsRequest)(nil),    // 2: voyagers.v1.ListPlanetsRequest
+	(*ListPlanetsResponse)(nil),   // 3: voyagers.v1.ListPlanetsResponse
+	(*StreamPlanetsRequest)(nil),  // 4: voyagers.v1.StreamPlanetsRequest
+	(*StreamPlanetsResponse)(nil), // 5: voyagers.v1.StreamPlanetsResponse
+	(*GetPlanetRequest)(nil),      // 6: voyagers.v1.GetPlanetRequest
+	(*GetPlanetResponse)(nil),     // 7: voyagers.v1.GetPlanetResponse
+	(*CreatePlanetRequest)(nil),   // 8: voyagers.v1.CreatePlanetRequest
+	(*CreatePlanetResponse)(nil),  // 9: voyagers.v1.CreatePlanetResponse
+	(*UpdatePlanetRequest)(nil),   // 10: voyagers.v1.UpdatePlanetRequest
+	(*UpdatePlanetResponse)(nil),  // 11: voyagers.v1.UpdatePlanetResponse
+	(*DeletePlanetRequest)(nil),   // 12: voyagers.v1.DeletePlanetRequest
+	(*DeletePlanetResponse)(nil),  // 13: voyagers.v1.DeletePlanetResponse
+	(*GetFuelCostRequest)(nil),    // 14: voyagers.v1.GetFuelCostRequest
+	(*GetFuelCostResponse)(nil),   // 15: voyagers.v1.GetFuelCostResponse
+	nil,                           // 16: voyagers.v1.ListPlanetsRequest.FiltersEntry
+	nil,                           // 17: voyagers.v1.StreamPlanetsRequest.FiltersEntry
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*structpb.Value)(nil),        // 19: google.protobuf.Value
+}
+var file_voyagers_v1_planets_proto_depIdxs = []int32{
+	18, // 0: voyagers.v1.Planet.created_at:type_name -> google.protobuf.Timestamp
+	18, // 1: voyagers.v1.Planet.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 2: voyagers.v1.FilterParam.eq:type_name -> google.protobuf.Value
+	19, // 3: voyagers.v1.FilterParam.neq:type_name -> google.protobuf.Value
+	19, // 4: voyagers.v1.FilterParam.gt:type_name -> google.protobuf.Value
+	19, // 5: voyagers.v1.FilterParam.gte:type_name -> google.protobuf.Value
+	19, // 6: voyagers.v1.FilterParam.lt:type_name -> google.protobuf.Value
+	19, // 7: voyagers.v1.FilterParam.lte:type_name -> google.protobuf.Value
+	16, // 8: voyagers.v1.ListPlanetsRequest.filters:type_name -> voyagers.v1.ListPlanetsRequest.FiltersEntry
+	0,  // 9: voyagers.v1.ListPlanetsResponse.planets:type_name -> voyagers.v1.Planet
+	17, // 10: voyagers.v1.StreamPlanetsRequest.filters:type_name -> voyagers.v1.StreamPlanetsRequest.FiltersEntry
+	0,  // 11: voyagers.v1.StreamPlanetsResponse.planet:type_name -> voyagers.v1.Planet
+	0,  // 12: voyagers.v1.GetPlanetResponse.planet:type_name -> voyagers.v1.Planet
+	0,  // 13: voyagers.v1.CreatePlanetRequest.planet:type_name -> voyagers.v1.Planet
+	0,  // 14: voyagers.v1.CreatePlanetResponse.planet:type_name -> voyagers.v1.Planet
+	0,  // 15: voyagers.v1.UpdatePlanetRequest.planet:type_name -> voyagers.v1.Planet
+	0,  // 16: voyagers.v1.UpdatePlanetResponse.planet:type_name -> voyagers.v1.Planet
+	1,  // 17: voyagers.v1.ListPlanetsRequest.FiltersEntry.value:type_name -> voyagers.v1.FilterParam
+	1,  // 18: voyagers.v1.StreamPlanetsRequest.FiltersEntry.value:type_name -> voyagers.v1.FilterParam
+	2,  // 19: voyagers.v1.PlanetService.ListPlanets:input_type -> voyagers.v1.ListPlanetsRequest
+	4,  // 20: voyagers.v1.PlanetService.StreamPlanets:input_type -> voyagers.v1.StreamPlanetsRequest
+	6,  // 21: voyagers.v1.PlanetService.GetPlanet:input_type -> voyagers.v1.GetPlanetRequest
+	8,  // 22: voyagers.v1.PlanetService.CreatePlanet:input_type -> voyagers.v1.CreatePlanetRequest
+	10, // 23: voyagers.v1.PlanetService.UpdatePlanet:input_type -> voyagers.v1.UpdatePlanetRequest
+	12, // 24: voyagers.v1.PlanetService.DeletePlanet:input_type -> voyagers.v1.DeletePlanetRequest
+	14, // 25: voyagers.v1.PlanetService.GetFuelCost:input_type -> voyagers.v1.GetFuelCostRequest
+	3,  // 26: voyagers.v1.PlanetService.ListPlanets:output_type -> voyagers.v1.ListPlanetsResponse
+	5,  // 27: voyagers.v1.PlanetService.StreamPlanets:output_type -> voyagers.v1.StreamPlanetsResponse
+	7,  // 28: voyagers.v1.PlanetService.GetPlanet:output_type -> voyagers.v1.GetPlanetResponse
+	9,  // 29: voyagers.v1.PlanetService.CreatePlanet:output_type -> voyagers.v1.CreatePlanetResponse
+	11, // 30: voyagers.v1.PlanetService.UpdatePlanet:output_type -> voyagers.v1.UpdatePlanetResponse
+	13, // 31: voyagers.v1.PlanetService.DeletePlanet:output_type -> voyagers.v1.DeletePlanetResponse
+	15, // 32: voyagers.v1.PlanetService.GetFuelCost:output_type -> voyagers.v1.GetFuelCostResponse
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_voyagers_v1_planets_proto_init() }
+func file_voyagers_v1_planets_proto_init() {
+	if File_voyagers_v1_planets_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_voyagers_v1_planets_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Planet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*FilterParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPlanetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPlanetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamPlanetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamPlanetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPlanetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPlanetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*CreatePlanetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*CreatePlanetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdatePlanetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdatePlanetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*DeletePlanetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*DeletePlanetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetFuelCostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_voyagers_v1_planets_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetFuelCostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_voyagers_v1_planets_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_voyagers_v1_planets_proto_goTypes,
+		DependencyIndexes: file_voyagers_v1_planets_proto_depIdxs,
+		MessageInfos:      file_voyagers_v1_planets_proto_msgTypes,
+	}.Build()
+	File_voyagers_v1_planets_proto = out.File
+	file_voyagers_v1_planets_proto_rawDesc = nil
+	file_voyagers_v1_planets_proto_goTypes = nil
+	file_voyagers_v1_planets_proto_depIdxs = nil
+}
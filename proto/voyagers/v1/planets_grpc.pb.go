@@ -0,0 +1,376 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: voyagers/v1/planets.proto
+
+package voyagersv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	PlanetService_ListPlanets_FullMethodName   = "/voyagers.v1.PlanetService/ListPlanets"
+	PlanetService_StreamPlanets_FullMethodName = "/voyagers.v1.PlanetService/StreamPlanets"
+	PlanetService_GetPlanet_FullMethodName     = "/voyagers.v1.PlanetService/GetPlanet"
+	PlanetService_CreatePlanet_FullMethodName  = "/voyagers.v1.PlanetService/CreatePlanet"
+	PlanetService_UpdatePlanet_FullMethodName  = "/voyagers.v1.PlanetService/UpdatePlanet"
+	PlanetService_DeletePlanet_FullMethodName  = "/voyagers.v1.PlanetService/DeletePlanet"
+	PlanetService_GetFuelCost_FullMethodName   = "/voyagers.v1.PlanetService/GetFuelCost"
+)
+
+// PlanetServiceClient is the client API for PlanetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PlanetService mirrors the REST planet API.
+type PlanetServiceClient interface {
+	// ListPlanets returns one page of planets matching the filters.
+	ListPlanets(ctx context.Context, in *ListPlanetsRequest, opts ...grpc.CallOption) (*ListPlanetsResponse, error)
+	// StreamPlanets streams every planet matching the filters, for result sets too large for one response.
+	StreamPlanets(ctx context.Context, in *StreamPlanetsRequest, opts ...grpc.CallOption) (PlanetService_StreamPlanetsClient, error)
+	GetPlanet(ctx context.Context, in *GetPlanetRequest, opts ...grpc.CallOption) (*GetPlanetResponse, error)
+	CreatePlanet(ctx context.Context, in *CreatePlanetRequest, opts ...grpc.CallOption) (*CreatePlanetResponse, error)
+	UpdatePlanet(ctx context.Context, in *UpdatePlanetRequest, opts ...grpc.CallOption) (*UpdatePlanetResponse, error)
+	DeletePlanet(ctx context.Context, in *DeletePlanetRequest, opts ...grpc.CallOption) (*DeletePlanetResponse, error)
+	// GetFuelCost estimates the fuel needed to take a crew to the planet.
+	GetFuelCost(ctx context.Context, in *GetFuelCostRequest, opts ...grpc.CallOption) (*GetFuelCostResponse, error)
+}
+
+type planetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlanetServiceClient(cc grpc.ClientConnInterface) PlanetServiceClient {
+	return &planetServiceClient{cc}
+}
+
+func (c *planetServiceClient) ListPlanets(ctx context.Context, in *ListPlanetsRequest, opts ...grpc.CallOption) (*ListPlanetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlanetsResponse)
+	err := c.cc.Invoke(ctx, PlanetService_ListPlanets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) StreamPlanets(ctx context.Context, in *StreamPlanetsRequest, opts ...grpc.CallOption) (PlanetService_StreamPlanetsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PlanetService_ServiceDesc.Streams[0], PlanetService_StreamPlanets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &planetServiceStreamPlanetsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PlanetService_StreamPlanetsClient interface {
+	Recv() (*StreamPlanetsResponse, error)
+	grpc.ClientStream
+}
+
+type planetServiceStreamPlanetsClient struct {
+	grpc.ClientStream
+}
+
+func (x *planetServiceStreamPlanetsClient) Recv() (*StreamPlanetsResponse, error) {
+	m := new(StreamPlanetsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *planetServiceClient) GetPlanet(ctx context.Context, in *GetPlanetRequest, opts ...grpc.CallOption) (*GetPlanetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlanetResponse)
+	err := c.cc.Invoke(ctx, PlanetService_GetPlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) CreatePlanet(ctx context.Context, in *CreatePlanetRequest, opts ...grpc.CallOption) (*CreatePlanetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePlanetResponse)
+	err := c.cc.Invoke(ctx, PlanetService_CreatePlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) UpdatePlanet(ctx context.Context, in *UpdatePlanetRequest, opts ...grpc.CallOption) (*UpdatePlanetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePlanetResponse)
+	err := c.cc.Invoke(ctx, PlanetService_UpdatePlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) DeletePlanet(ctx context.Context, in *DeletePlanetRequest, opts ...grpc.CallOption) (*DeletePlanetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePlanetResponse)
+	err := c.cc.Invoke(ctx, PlanetService_DeletePlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planetServiceClient) GetFuelCost(ctx context.Context, in *GetFuelCostRequest, opts ...grpc.CallOption) (*GetFuelCostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFuelCostResponse)
+	err := c.cc.Invoke(ctx, PlanetService_GetFuelCost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlanetServiceServer is the server API for PlanetService service.
+// All implementations must embed UnimplementedPlanetServiceServer
+// for forward compatibility
+//
+// PlanetService mirrors the REST planet API.
+type PlanetServiceServer interface {
+	// ListPlanets returns one page of planets matching the filters.
+	ListPlanets(context.Context, *ListPlanetsRequest) (*ListPlanetsResponse, error)
+	// StreamPlanets streams every planet matching the filters, for result sets too large for one response.
+	StreamPlanets(*StreamPlanetsRequest, PlanetService_StreamPlanetsServer) error
+	GetPlanet(context.Context, *GetPlanetRequest) (*GetPlanetResponse, error)
+	CreatePlanet(context.Context, *CreatePlanetRequest) (*CreatePlanetResponse, error)
+	UpdatePlanet(context.Context, *UpdatePlanetRequest) (*UpdatePlanetResponse, error)
+	DeletePlanet(context.Context, *DeletePlanetRequest) (*DeletePlanetResponse, error)
+	// GetFuelCost estimates the fuel needed to take a crew to the planet.
+	GetFuelCost(context.Context, *GetFuelCostRequest) (*GetFuelCostResponse, error)
+	mustEmbedUnimplementedPlanetServiceServer()
+}
+
+// UnimplementedPlanetServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPlanetServiceServer struct {
+}
+
+func (UnimplementedPlanetServiceServer) ListPlanets(context.Context, *ListPlanetsRequest) (*ListPlanetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlanets not implemented")
+}
+func (UnimplementedPlanetServiceServer) StreamPlanets(*StreamPlanetsRequest, PlanetService_StreamPlanetsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPlanets not implemented")
+}
+func (UnimplementedPlanetServiceServer) GetPlanet(context.Context, *GetPlanetRequest) (*GetPlanetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlanet not implemented")
+}
+func (UnimplementedPlanetServiceServer) CreatePlanet(context.Context, *CreatePlanetRequest) (*CreatePlanetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePlanet not implemented")
+}
+func (UnimplementedPlanetServiceServer) UpdatePlanet(context.Context, *UpdatePlanetRequest) (*UpdatePlanetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePlanet not implemented")
+}
+func (UnimplementedPlanetServiceServer) DeletePlanet(context.Context, *DeletePlanetRequest) (*DeletePlanetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlanet not implemented")
+}
+func (UnimplementedPlanetServiceServer) GetFuelCost(context.Context, *GetFuelCostRequest) (*GetFuelCostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFuelCost not implemented")
+}
+func (UnimplementedPlanetServiceServer) mustEmbedUnimplementedPlanetServiceServer() {}
+
+// UnsafePlanetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlanetServiceServer will
+// result in compilation errors.
+type UnsafePlanetServiceServer interface {
+	mustEmbedUnimplementedPlanetServiceServer()
+}
+
+func RegisterPlanetServiceServer(s grpc.ServiceRegistrar, srv PlanetServiceServer) {
+	s.RegisterService(&PlanetService_ServiceDesc, srv)
+}
+
+func _PlanetService_ListPlanets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlanetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).ListPlanets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_ListPlanets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).ListPlanets(ctx, req.(*ListPlanetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_StreamPlanets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPlanetsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlanetServiceServer).StreamPlanets(m, &planetServiceStreamPlanetsServer{ServerStream: stream})
+}
+
+type PlanetService_StreamPlanetsServer interface {
+	Send(*StreamPlanetsResponse) error
+	grpc.ServerStream
+}
+
+type planetServiceStreamPlanetsServer struct {
+	grpc.ServerStream
+}
+
+func (x *planetServiceStreamPlanetsServer) Send(m *StreamPlanetsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PlanetService_GetPlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlanetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).GetPlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_GetPlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).GetPlanet(ctx, req.(*GetPlanetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_CreatePlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePlanetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).CreatePlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_CreatePlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).CreatePlanet(ctx, req.(*CreatePlanetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_UpdatePlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlanetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).UpdatePlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_UpdatePlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).UpdatePlanet(ctx, req.(*UpdatePlanetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_DeletePlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePlanetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).DeletePlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_DeletePlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).DeletePlanet(ctx, req.(*DeletePlanetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanetService_GetFuelCost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFuelCostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanetServiceServer).GetFuelCost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanetService_GetFuelCost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanetServiceServer).GetFuelCost(ctx, req.(*GetFuelCostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlanetService_ServiceDesc is the grpc.ServiceDesc for PlanetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlanetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "voyagers.v1.PlanetService",
+	HandlerType: (*PlanetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPlanets",
+			Handler:    _PlanetService_ListPlanets_Handler,
+		},
+		{
+			MethodName: "GetPlanet",
+			Handler:    _PlanetService_GetPlanet_Handler,
+		},
+		{
+			MethodName: "CreatePlanet",
+			Handler:    _PlanetService_CreatePlanet_Handler,
+		},
+		{
+			MethodName: "UpdatePlanet",
+			Handler:    _PlanetService_UpdatePlanet_Handler,
+		},
+		{
+			MethodName: "DeletePlanet",
+			Handler:    _PlanetService_DeletePlanet_Handler,
+		},
+		{
+			MethodName: "GetFuelCost",
+			Handler:    _PlanetService_GetFuelCost_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPlanets",
+			Handler:       _PlanetService_StreamPlanets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "voyagers/v1/planets.proto",
+}
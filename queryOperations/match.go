@@ -0,0 +1,126 @@
+package queryoperations
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Match reports whether values, keyed by column, satisfy the filters of params the way Filter would in SQL.
+// Filters on fields that are not allowed are ignored, as Filter ignores them.
+func Match(params *QueryParams, values map[string]interface{}, allowedFilters *map[string]string) bool {
+	for field, filter := range params.Filters {
+		dataType, allowed := (*allowedFilters)[field]
+		if !allowed {
+			continue
+		}
+		if !filter.matches(values[field], dataType) {
+			return false
+		}
+	}
+	return true
+}
+
+func (filter FilterParam) matches(value interface{}, dataType string) bool {
+	if value == nil {
+		// NULL columns satisfy no SQL condition
+		return filter.Eq == nil && filter.Neq == nil && filter.Gt == nil && filter.Gte == nil && filter.Lt == nil && filter.Lte == nil &&
+			filter.Like == "" && len(filter.In) == 0 && len(filter.NotIn) == 0
+	}
+
+	conditions := []struct {
+		operand interface{}
+		holds   func(int) bool
+	}{
+		{filter.Eq, func(order int) bool { return order == 0 }},
+		{filter.Neq, func(order int) bool { return order != 0 }},
+		{filter.Gt, func(order int) bool { return order > 0 }},
+		{filter.Gte, func(order int) bool { return order >= 0 }},
+		{filter.Lt, func(order int) bool { return order < 0 }},
+		{filter.Lte, func(order int) bool { return order <= 0 }},
+	}
+	for _, condition := range conditions {
+		if condition.operand == nil {
+			continue
+		}
+		order, comparable := compare(value, condition.operand, dataType)
+		if !comparable || !condition.holds(order) {
+			return false
+		}
+	}
+
+	if filter.Like != "" && dataType == "string" && !like(fmt.Sprint(value), filter.Like) {
+		return false
+	}
+	if len(filter.In) > 0 && !contains(filter.In, value, dataType) {
+		return false
+	}
+	if len(filter.NotIn) > 0 && contains(filter.NotIn, value, dataType) {
+		return false
+	}
+	return true
+}
+
+// compare orders value against operand, numerically for numeric columns and as text otherwise.
+func compare(value interface{}, operand interface{}, dataType string) (int, bool) {
+	if dataType == "int" || dataType == "float" {
+		a, okA := number(value)
+		b, okB := number(operand)
+		if !okA || !okB {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	}
+	return strings.Compare(fmt.Sprint(value), fmt.Sprint(operand)), true
+}
+
+func number(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case uint:
+		return float64(value), true
+	case float64:
+		return value, true
+	case string:
+		parsed, err := strconv.ParseFloat(value, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+func contains(candidates []string, value interface{}, dataType string) bool {
+	for _, candidate := range candidates {
+		if order, comparable := compare(value, candidate, dataType); comparable && order == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// like mirrors the case-insensitive "%pattern%" match Filter sends to the database, including its _ and % wildcards.
+func like(value string, pattern string) bool {
+	var expression strings.Builder
+	expression.WriteString("(?is)")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expression.WriteString(".*")
+		case '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	matched, err := regexp.MatchString(expression.String(), value)
+	return err == nil && matched
+}
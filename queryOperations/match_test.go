@@ -0,0 +1,35 @@
+package queryoperations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	allowedFilters := map[string]string{"name": "string", "distance": "int", "mass": "float", "star_id": "int"}
+	values := map[string]interface{}{"name": "Pluto", "distance": int64(50), "mass": 2.5, "star_id": nil}
+
+	tests := []struct {
+		name     string
+		filters  map[string]FilterParam
+		expected bool
+	}{
+		{"no filters", nil, true},
+		{"numeric range", map[string]FilterParam{"distance": {Gt: 10.0, Lte: 50.0}}, true},
+		{"numeric range excludes", map[string]FilterParam{"distance": {Gt: 50.0}}, false},
+		{"numeric text operand", map[string]FilterParam{"mass": {Eq: "2.5"}}, true},
+		{"text equality", map[string]FilterParam{"name": {Neq: "Pluto"}}, false},
+		{"like is case insensitive", map[string]FilterParam{"name": {Like: "lut"}}, true},
+		{"like wildcards", map[string]FilterParam{"name": {Like: "p_u%o"}}, true},
+		{"like ignores numeric fields", map[string]FilterParam{"distance": {Like: "x"}}, true},
+		{"in on numbers", map[string]FilterParam{"distance": {In: []string{"20", "50"}}}, true},
+		{"not in", map[string]FilterParam{"name": {NotIn: []string{"Pluto"}}}, false},
+		{"null matches no condition", map[string]FilterParam{"star_id": {NotIn: []string{"1"}}}, false},
+		{"unknown fields are ignored", map[string]FilterParam{"secret": {Eq: "x"}}, true},
+	}
+	for _, test := range tests {
+		params := QueryParams{Filters: test.filters}
+		assert.Equal(t, test.expected, Match(&params, values, &allowedFilters), test.name)
+	}
+}
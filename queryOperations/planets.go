@@ -3,9 +3,14 @@ package queryoperations
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FilterParam struct {
@@ -48,35 +53,96 @@ func (q *QueryParams) BindQuery(c *gin.Context) error {
     return nil
 }
 
+// Values encodes the params as the query string understood by BindQuery, filters as filter[field]=<json>.
+func (q *QueryParams) Values() (url.Values, error) {
+    values := url.Values{}
+    if q.Sort != "" {
+        values.Set("sort", q.Sort)
+    }
+    if q.Page != 0 {
+        values.Set("page", strconv.Itoa(q.Page))
+    }
+    if q.Limit != 0 {
+        values.Set("limit", strconv.Itoa(q.Limit))
+    }
+
+    fields := make([]string, 0, len(q.Filters))
+    for field := range q.Filters {
+        fields = append(fields, field)
+    }
+    sort.Strings(fields)
+    for _, field := range fields {
+        encoded, err := json.Marshal(q.Filters[field])
+        if err != nil {
+            return nil, fmt.Errorf("invalid filter for field %s: %v", field, err)
+        }
+        values.Set("filter["+field+"]", string(encoded))
+    }
+    return values, nil
+}
+
+// ValidateSort rejects sort expressions that are not "field", "field asc" or "field desc" on allowed fields,
+// the sort string is passed to ORDER BY verbatim.
+func (q *QueryParams) ValidateSort(allowedFilters *map[string]string) error {
+    if q.Sort == "" {
+        return nil
+    }
+    for _, term := range strings.Split(q.Sort, ",") {
+        parts := strings.Fields(term)
+        if len(parts) == 0 || len(parts) > 2 {
+            return fmt.Errorf("invalid sort expression %q", term)
+        }
+        if _, allowed := (*allowedFilters)[parts[0]]; !allowed {
+            return fmt.Errorf("cannot sort by field %s", parts[0])
+        }
+        if len(parts) == 2 && !strings.EqualFold(parts[1], "asc") && !strings.EqualFold(parts[1], "desc") {
+            return fmt.Errorf("invalid sort direction %q for field %s", parts[1], parts[0])
+        }
+    }
+    return nil
+}
+
+// SortFields returns the field of every term of the sort expression, such as [mass name] for "mass desc, name".
+func (q *QueryParams) SortFields() []string {
+    var fields []string
+    for _, term := range strings.Split(q.Sort, ",") {
+        if parts := strings.Fields(term); len(parts) > 0 {
+            fields = append(fields, parts[0])
+        }
+    }
+    return fields
+}
+
 func Filter(db *gorm.DB, params *QueryParams, allowedFilters *map[string]string) *gorm.DB {
 	for field, filter := range params.Filters {
         if dataType, allowed := (*allowedFilters)[field]; allowed {
+            column := column(db, field)
             if filter.Eq != nil {
-                db = db.Where(fmt.Sprintf("%s = ?", field), filter.Eq)
+                db = db.Where(clause.Eq{Column: column, Value: filter.Eq})
             }
             if filter.Neq != nil {
-                db = db.Where(fmt.Sprintf("%s != ?", field), filter.Neq)
+                db = db.Where(clause.Neq{Column: column, Value: filter.Neq})
             }
             if filter.Gt != nil {
-                db = db.Where(fmt.Sprintf("%s > ?", field), filter.Gt)
+                db = db.Where(clause.Gt{Column: column, Value: filter.Gt})
             }
             if filter.Gte != nil {
-                db = db.Where(fmt.Sprintf("%s >= ?", field), filter.Gte)
+                db = db.Where(clause.Gte{Column: column, Value: filter.Gte})
             }
             if filter.Lt != nil {
-                db = db.Where(fmt.Sprintf("%s < ?", field), filter.Lt)
+                db = db.Where(clause.Lt{Column: column, Value: filter.Lt})
             }
             if filter.Lte != nil {
-                db = db.Where(fmt.Sprintf("%s <= ?", field), filter.Lte)
+                db = db.Where(clause.Lte{Column: column, Value: filter.Lte})
             }
             if filter.Like != "" && dataType == "string" {
-                db = db.Where(fmt.Sprintf("%s LIKE ?", field), "%"+filter.Like+"%")
+                db = db.Where(clause.Like{Column: column, Value: "%"+filter.Like+"%"})
             }
             if len(filter.In) > 0 {
-                db = db.Where(fmt.Sprintf("%s IN (?)", field), filter.In)
+                db = db.Where(clause.IN{Column: column, Values: values(filter.In)})
             }
             if len(filter.NotIn) > 0 {
-                db = db.Where(fmt.Sprintf("%s NOT IN (?)", field), filter.NotIn)
+                db = db.Where(clause.Not(clause.IN{Column: column, Values: values(filter.NotIn)}))
             }
         }
     }
@@ -84,12 +150,91 @@ func Filter(db *gorm.DB, params *QueryParams, allowedFilters *map[string]string)
 }
 
 func Sort(db *gorm.DB, params *QueryParams) *gorm.DB {
-    if params.Sort != "" {
-        db = db.Order(params.Sort)
+    if params.Sort == "" {
+        return db
+    }
+    for _, term := range strings.Split(params.Sort, ",") {
+        parts := strings.Fields(term)
+        if len(parts) == 0 {
+            continue
+        }
+        db = db.Order(clause.OrderByColumn{Column: column(db, parts[0]), Desc: len(parts) == 2 && strings.EqualFold(parts[1], "desc")})
+    }
+    return db
+}
+
+// Column resolves a filter or sort field: "relation.field" names a column of a joined relation,
+// anything else a column of the queried table, qualified so joins cannot make it ambiguous.
+func Column(field string) clause.Column {
+    if relation, name, found := strings.Cut(field, "."); found {
+        return clause.Column{Table: relation, Name: name}
+    }
+    return clause.Column{Table: clause.CurrentTable, Name: field}
+}
+
+// computedKey is the gorm setting holding the fields registered with Compute.
+const computedKey = "queryoperations:computed"
+
+// Compute makes Filter and Sort treat field as the SQL expression instead of a column, for values computed per row.
+// The expression is written verbatim and must not contain user input.
+func Compute(db *gorm.DB, field string, expression string) *gorm.DB {
+    computed := map[string]string{field: expression}
+    if existing, ok := db.Get(computedKey); ok {
+        for name, other := range existing.(map[string]string) {
+            if name != field {
+                computed[name] = other
+            }
+        }
+    }
+    return db.Set(computedKey, computed)
+}
+
+// column resolves field to its computed expression when one was registered with Compute, to Column otherwise.
+func column(db *gorm.DB, field string) clause.Column {
+    if computed, ok := db.Get(computedKey); ok {
+        if expression, found := computed.(map[string]string)[field]; found {
+            return clause.Column{Name: expression, Raw: true}
+        }
+    }
+    return Column(field)
+}
+
+// Join adds the JOIN clause of every relation referenced by a "relation.field" filter or sort term of params.
+// relations maps each relation name to a JOIN exposing the related table under that alias.
+func Join(db *gorm.DB, params *QueryParams, relations map[string]string) *gorm.DB {
+    used := map[string]bool{}
+    for field := range params.Filters {
+        if relation, _, found := strings.Cut(field, "."); found {
+            used[relation] = true
+        }
+    }
+    for _, field := range params.SortFields() {
+        if relation, _, found := strings.Cut(field, "."); found {
+            used[relation] = true
+        }
+    }
+
+    names := make([]string, 0, len(used))
+    for relation := range used {
+        names = append(names, relation)
+    }
+    sort.Strings(names)
+    for _, relation := range names {
+        if join, known := relations[relation]; known {
+            db = db.Joins(join)
+        }
     }
     return db
 }
 
+func values(texts []string) []interface{} {
+    converted := make([]interface{}, len(texts))
+    for i, text := range texts {
+        converted[i] = text
+    }
+    return converted
+}
+
 func Paginate(db *gorm.DB, params *QueryParams) *gorm.DB {
     if params.Page > 0 && params.Limit > 0 {
 		offset := (params.Page - 1) * params.Limit
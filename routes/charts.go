@@ -0,0 +1,178 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/charts"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultHistogramBins = 10
+	maxHistogramBins     = 100
+)
+
+// typeColors colours planets by type in catalogue charts, in the order of kinds.
+func typeColors(kinds []models.PlanetTypeDefinition) map[models.PlanetType]int {
+	colors := map[models.PlanetType]int{}
+	for i, kind := range kinds {
+		colors[kind.Name] = i % len(charts.Palette)
+	}
+	return colors
+}
+
+func FuelCostChartHandler(db *gorm.DB) gin.HandlerFunc {
+	// fuelCostChart draws the fuel cost of a trip to a planet against the crew size.
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		planetId, err := parseID(context, "id", "planet")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		crews, err := parseCrewRange(context.DefaultQuery("crew", "1..20"), context.DefaultQuery("step", "1"))
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		format, err := parseChartFormat(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		planet, err := planetService.Get(context.Request.Context(), planetId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		series := charts.Series{Name: planet.Name, Color: charts.Palette[0], Lines: true}
+		for _, crew := range crews {
+			series.Points = append(series.Points, charts.Point{X: float64(crew), Y: planet.GetFuelCost(crew)})
+		}
+		renderChart(context, format, charts.Chart{
+			Title:  "Fuel cost to " + planet.Name,
+			XLabel: "Crew size",
+			YLabel: "Fuel cost",
+			Series: []charts.Series{series},
+		})
+	}
+}
+
+func DistanceRadiusChartHandler(db *gorm.DB) gin.HandlerFunc {
+	// distanceRadiusChart plots the distance against the radius of the filtered planets, coloured by type.
+	planetService := services.NewPlanets(db)
+	typeService := services.NewPlanetTypes(db)
+	return func (context *gin.Context) {
+		format, err := parseChartFormat(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		// a series per planet type found, in registry order
+		kinds, err := typeService.List(context.Request.Context())
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		colors := typeColors(kinds)
+		byType := map[models.PlanetType]*charts.Series{}
+		err = planetService.Each(context.Request.Context(), &params, func(planet models.Planet) error {
+			typeSeries, ok := byType[planet.Type]
+			if !ok {
+				typeSeries = &charts.Series{Name: string(planet.Type), Color: charts.Palette[colors[planet.Type]]}
+				byType[planet.Type] = typeSeries
+			}
+			typeSeries.Points = append(typeSeries.Points, charts.Point{X: float64(planet.Distance), Y: planet.Radius})
+			return nil
+		})
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		series := []charts.Series{}
+		for _, kind := range kinds {
+			if typeSeries, ok := byType[kind.Name]; ok {
+				series = append(series, *typeSeries)
+			}
+		}
+
+		renderChart(context, format, charts.Chart{
+			Title:  "Distance and radius of planets",
+			XLabel: "Distance",
+			YLabel: "Radius",
+			Series: series,
+		})
+	}
+}
+
+func HistogramChartHandler(db *gorm.DB) gin.HandlerFunc {
+	// histogramChart counts the filtered planets in bins of a numeric field.
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		format, err := parseChartFormat(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		bins, err := strconv.Atoi(context.DefaultQuery("bins", strconv.Itoa(defaultHistogramBins)))
+		if err != nil || bins <= 0 || bins > maxHistogramBins {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Bins should be between 1 and %d.", maxHistogramBins)))
+			return
+		}
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		field := context.Query("field")
+		values, err := planetService.Values(context.Request.Context(), &params, field)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		renderChart(context, format, charts.Chart{
+			Title:  "Planets by " + field,
+			XLabel: field,
+			YLabel: "Planets",
+			Bars:   charts.Histogram(values, bins),
+		})
+	}
+}
+
+// parseChartFormat reads the format query parameter, SVG by default.
+func parseChartFormat(context *gin.Context) (charts.Format, error) {
+	name := context.DefaultQuery("format", string(charts.SVG))
+	format, err := charts.ParseFormat(name)
+	if err != nil {
+		return "", problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Unknown format %s, use svg or png.", name))
+	}
+	return format, nil
+}
+
+// renderChart renders the whole image before responding, so that a failure is still reported as a problem.
+func renderChart(context *gin.Context, format charts.Format, chart charts.Chart) {
+	var image bytes.Buffer
+	if err := chart.Render(&image, format); err != nil {
+		problems.Abort(context, err)
+		return
+	}
+	context.Data(http.StatusOK, format.ContentType(), image.Bytes())
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"bytes"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharts(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", saturn)
+
+	get := func(path string, query url.Values) (*http.Response, string) {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + path + "?" + query.Encode())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	resp, body := get("/planets/1/charts/fuel-cost", url.Values{"crew": {"1..5"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	assert.Contains(t, body, ">Fuel cost to Jupiter</text>")
+	assert.Equal(t, 5, strings.Count(body, "<circle"), "a point per crew size")
+
+	resp, body = get("/planets/2/charts/fuel-cost", url.Values{"format": {"png"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	_, err := png.Decode(bytes.NewReader([]byte(body)))
+	assert.NoError(t, err)
+
+	resp, body = get("/charts/distance-radius", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, strings.Count(body, "<circle"))
+	assert.Equal(t, 2, strings.Count(body, `r="3.5" fill="#e67e22"`), "gas giants are coloured apart")
+	assert.Contains(t, body, ">gas_giant</text>")
+	assert.Contains(t, body, ">terrestrial</text>")
+
+	_, body = get("/charts/distance-radius", url.Values{"filter[type]": {`{"eq": "terrestrial"}`}})
+	assert.Equal(t, 1, strings.Count(body, "<circle"))
+
+	resp, body = get("/charts/histogram", url.Values{"field": {"distance"}, "bins": {"2"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, strings.Count(body, `fill="#26a69a"`), "a bar per bin")
+	assert.Contains(t, body, ">Planets by distance</text>")
+
+	// derived properties filter, sort and fill histograms like stored ones
+	resp, body = get("/charts/distance-radius", url.Values{"filter[surface_gravity]": {`{"gt": 0}`}, "sort": {"density"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, strings.Count(body, "<circle"))
+	resp, body = get("/charts/histogram", url.Values{"field": {"density"}, "bins": {"2"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, strings.Count(body, `fill="#26a69a"`))
+
+	_, body = get("/charts/histogram", url.Values{"field": {"star.mass"}})
+	assert.Contains(t, body, ">No data</text>", "planets without a star have no star mass")
+
+	errorTests := []struct {
+		path   string
+		query  url.Values
+		status int
+	}{
+		{"/planets/9/charts/fuel-cost", nil, http.StatusNotFound},
+		{"/planets/x/charts/fuel-cost", nil, http.StatusBadRequest},
+		{"/planets/1/charts/fuel-cost", url.Values{"crew": {"0"}}, http.StatusBadRequest},
+		{"/planets/1/charts/fuel-cost", url.Values{"format": {"gif"}}, http.StatusBadRequest},
+		{"/charts/distance-radius", url.Values{"sort": {"colour"}}, http.StatusBadRequest},
+		{"/charts/histogram", nil, http.StatusBadRequest},
+		{"/charts/histogram", url.Values{"field": {"name"}}, http.StatusBadRequest},
+		{"/charts/histogram", url.Values{"field": {"mass"}, "bins": {"0"}}, http.StatusBadRequest},
+		{"/charts/histogram", url.Values{"field": {"mass"}, "bins": {"101"}}, http.StatusBadRequest},
+	}
+	for _, test := range errorTests {
+		resp, _ := get(test.path, test.query)
+		assert.Equal(t, test.status, resp.StatusCode, "%s?%s", test.path, test.query.Encode())
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"), "%s?%s", test.path, test.query.Encode())
+	}
+}
@@ -0,0 +1,210 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+const (
+	// eventBatchSize is the number of events read from the log at a time while catching up.
+	eventBatchSize = 100
+	// eventPollInterval bounds the delay for events recorded by other instances, which do not signal this one.
+	eventPollInterval = 2 * time.Second
+	// eventHeartbeatInterval keeps idle streams open through proxies.
+	eventHeartbeatInterval = 15 * time.Second
+	eventWriteTimeout      = 10 * time.Second
+)
+
+var upgrader = websocket.Upgrader{}
+
+var (
+	streamsClosed    = make(chan struct{})
+	closeStreamsOnce sync.Once
+)
+
+// CloseStreams ends every open event stream, the HTTP server does not wait for or close them on shutdown.
+func CloseStreams() {
+	closeStreamsOnce.Do(func() { close(streamsClosed) })
+}
+
+func PlanetEventsHandler(db *gorm.DB) gin.HandlerFunc {
+	// planetEvents streams planet changes matching the filter[...] parameters as Server-Sent Events,
+	// or as WebSocket messages when the client asks for an upgrade.
+	eventService := services.NewEvents(db)
+	return func (context *gin.Context) {
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+		// events carry the planet alone, fields of its star and the properties derived from it cannot be matched
+		for field := range params.Filters {
+			if _, allowed := models.EventPlanetFilters[field]; !allowed {
+				problems.Abort(context, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Cannot filter events on %s.", field)))
+				return
+			}
+		}
+		canonical, err := services.CanonicalFilters(&params)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		lastEventId, err := lastEventID(context, eventService)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if websocket.IsWebSocketUpgrade(context.Request) {
+			streamWebSocket(context.Writer, context.Request, eventService, canonical, lastEventId)
+			return
+		}
+		streamSSE(context.Writer, context.Request, eventService, canonical, lastEventId)
+	}
+}
+
+// lastEventID reads where the stream resumes: the Last-Event-ID header sent by reconnecting EventSource clients,
+// then the lastEventId query parameter, otherwise the newest event so only later changes are sent.
+func lastEventID(context *gin.Context, eventService *services.Events) (uint, error) {
+	value := context.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = context.Query("lastEventId")
+	}
+	if value == "" {
+		return eventService.Latest(context.Request.Context())
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, problems.BadRequest(problems.InvalidQuery, "Could not parse the last event id.")
+	}
+	return uint(id), nil
+}
+
+func streamSSE(writer gin.ResponseWriter, request *http.Request, eventService *services.Events, params *queryoperations.QueryParams, lastEventId uint) {
+	// streams outlive the server write timeout
+	http.NewResponseController(writer).SetWriteDeadline(time.Time{})
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+	fmt.Fprintf(writer, "retry: %d\n\n", 3000)
+	writer.Flush()
+
+	err := follow(request.Context(), eventService, params, lastEventId, func(event models.PlanetEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+			return err
+		}
+		writer.Flush()
+		return nil
+	}, func() error {
+		if _, err := io.WriteString(writer, ": keepalive\n\n"); err != nil {
+			return err
+		}
+		writer.Flush()
+		return nil
+	})
+	if err != nil && request.Context().Err() == nil {
+		slog.WarnContext(request.Context(), "planet event stream failed", slog.String("error", err.Error()))
+	}
+}
+
+func streamWebSocket(writer http.ResponseWriter, request *http.Request, eventService *services.Events, params *queryoperations.QueryParams, lastEventId uint) {
+	conn, err := upgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		// the upgrader has already replied with an error status
+		return
+	}
+	defer conn.Close()
+
+	// the client only sends control frames, reading processes them and notices when it goes away
+	ctx, cancel := context.WithCancel(request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = follow(ctx, eventService, params, lastEventId, func(event models.PlanetEvent) error {
+		conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+		return conn.WriteJSON(event)
+	}, func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventWriteTimeout))
+	})
+	if err != nil && ctx.Err() == nil {
+		slog.WarnContext(ctx, "planet event stream failed", slog.String("error", err.Error()))
+	}
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(eventWriteTimeout))
+}
+
+// follow sends the logged events after lastEventId that match params, then waits for new ones,
+// until ctx is done, streams are closed or sending fails.
+func follow(ctx context.Context, eventService *services.Events, params *queryoperations.QueryParams, lastEventId uint, send func(models.PlanetEvent) error, heartbeat func() error) error {
+	// subscribe before reading the log so no event recorded in between is missed
+	signal, unsubscribe := eventService.Subscribe()
+	defer unsubscribe()
+	poll := time.NewTicker(eventPollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(eventHeartbeatInterval)
+	defer keepAlive.Stop()
+
+	for {
+		for {
+			events, err := eventService.After(ctx, lastEventId, eventBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				lastEventId = event.ID
+				if !queryoperations.Match(params, event.Planet.FilterValues(), &models.EventPlanetFilters) {
+					continue
+				}
+				if err := send(event); err != nil {
+					return err
+				}
+			}
+			if len(events) < eventBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-streamsClosed:
+			return nil
+		case <-signal:
+		case <-poll.C:
+		case <-keepAlive.C:
+			if err := heartbeat(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package routes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupEventServer(t *testing.T) *httptest.Server {
+	db, err := gorm.Open(database.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router, db)
+	server := httptest.NewServer(router)
+	t.Cleanup(func() {
+		server.Close()
+		sqlDB.Close()
+	})
+	return server
+}
+
+func send(t *testing.T, server *httptest.Server, method string, path string, body interface{}) {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, server.URL+path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		t.Fatalf("%s %s returned %d", method, path, resp.StatusCode)
+	}
+}
+
+// request sends body as JSON and decodes the JSON response.
+func request(t *testing.T, server *httptest.Server, method string, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(method, server.URL+path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	return resp.StatusCode, decoded
+}
+
+var (
+	jupiter = gin.H{"name": "Jupiter", "description": "A far away planet", "distance": 20, "radius": 9, "mass": 8, "type": "gas_giant"}
+	pluto   = gin.H{"name": "Pluto", "description": "A small planet", "distance": 50, "radius": 2, "mass": 2, "type": "terrestrial"}
+	saturn  = gin.H{"name": "Saturn", "description": "A ringed planet", "distance": 90, "radius": 8, "mass": 3, "type": "gas_giant"}
+)
+
+func TestPlanetEventsSSE(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	query := url.Values{"filter[type]": {`{"eq": "terrestrial"}`}}
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/planets/events?"+query.Encode(), nil)
+	req.Header.Set("Last-Event-ID", "0")
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open the stream: %v", err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// changes made while the stream is open are delivered after the replayed ones
+	send(t, server, "PUT", "/planets/2", gin.H{"name": "Pluto", "description": "A dwarf planet", "distance": 55, "radius": 2, "mass": 2, "type": "terrestrial"})
+	send(t, server, "POST", "/planets", saturn)
+	send(t, server, "DELETE", "/planets/2", nil)
+
+	expected := []struct {
+		id          string
+		eventType   models.PlanetEventType
+		description string
+	}{
+		{"2", models.PlanetCreated, "A small planet"},
+		{"3", models.PlanetUpdated, "A dwarf planet"},
+		{"5", models.PlanetDeleted, "A dwarf planet"},
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	for _, want := range expected {
+		fields := map[string]string{}
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" && fields["data"] != "" {
+				break
+			}
+			if name, value, found := strings.Cut(line, ": "); found {
+				fields[name] = value
+			}
+		}
+		var event models.PlanetEvent
+		json.Unmarshal([]byte(fields["data"]), &event)
+		assert.Equal(t, want.id, fields["id"])
+		assert.Equal(t, string(want.eventType), fields["event"])
+		assert.Equal(t, want.eventType, event.Type)
+		assert.EqualValues(t, 2, event.PlanetID)
+		assert.Equal(t, want.description, event.Planet.Description)
+	}
+}
+
+func TestPlanetEventsWebSocket(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+
+	dial := func(query url.Values) *websocket.Conn {
+		conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/planets/events?"+query.Encode(), nil)
+		if err != nil {
+			t.Fatalf("Failed to open the WebSocket: %v", err)
+		}
+		resp.Body.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+
+	// without a last event id only later changes are sent
+	live := dial(url.Values{})
+	replay := dial(url.Values{"lastEventId": {"0"}, "filter[name]": {`{"like": "pl"}`}})
+	dense := dial(url.Values{"lastEventId": {"0"}, "filter[density]": {`{"gt": 1}`}})
+	send(t, server, "POST", "/planets", pluto)
+
+	var event models.PlanetEvent
+	if assert.NoError(t, live.ReadJSON(&event)) {
+		assert.EqualValues(t, 2, event.ID)
+		assert.Equal(t, "Pluto", event.Planet.Name)
+	}
+	if assert.NoError(t, replay.ReadJSON(&event)) {
+		assert.EqualValues(t, 2, event.ID)
+		assert.Equal(t, models.PlanetCreated, event.Type)
+	}
+	// derived properties are matched too, Jupiter is far less dense than Pluto
+	if assert.NoError(t, dense.ReadJSON(&event)) {
+		assert.Equal(t, "Pluto", event.Planet.Name)
+	}
+}
+
+func TestPlanetEventsInvalidFilters(t *testing.T) {
+	server := setupEventServer(t)
+
+	for field, detail := range map[string]string{
+		"equilibrium_temperature": "Cannot filter events on equilibrium_temperature.",
+		"fuel_cost":               "Cannot filter events on fuel_cost.",
+		"star.mass":               "Cannot filter events on star.mass.",
+	} {
+		status, body := request(t, server, "GET", "/planets/events?"+url.Values{"filter[" + field + "]": {`{"gt": 1}`}}.Encode(), nil)
+		assert.Equal(t, http.StatusBadRequest, status, field)
+		assert.Equal(t, detail, body["detail"], field)
+	}
+}
+
+func TestPlanetEventsInvalidLastEventID(t *testing.T) {
+	server := setupEventServer(t)
+
+	req, _ := http.NewRequest("GET", server.URL+"/planets/events", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,281 @@
+package routes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxMatrixCrews bounds the crew sizes of one matrix, each is a column of every row.
+	maxMatrixCrews = 1000
+	// matrixFlushRows is the number of rows written between flushes to the client.
+	matrixFlushRows = 100
+	// tableCellWidth is the minimum width of text table cells, so blocks flushed separately line up.
+	tableCellWidth = 14
+)
+
+func FuelMatrixHandler(db *gorm.DB) gin.HandlerFunc {
+	// fuelMatrix streams the fuel cost of every selected planet for every crew size as JSON, CSV or an aligned text table.
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		crews, err := parseCrewRange(context.DefaultQuery("crew", "1"), context.DefaultQuery("step", "1"))
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		matrix, err := newMatrixWriter(context.DefaultQuery("format", "json"), context.Writer)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		// the header is only written with the first row, so that failures before it are still reported as problems
+		started := false
+		start := func() error {
+			started = true
+			context.Header("Content-Type", matrix.contentType())
+			context.Status(http.StatusOK)
+			return matrix.header(crews)
+		}
+		written := 0
+		err = planetService.EachSelected(context.Request.Context(), &params, func(planet models.Planet) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			costs := make([]float64, len(crews))
+			for i, crew := range crews {
+				costs[i] = planet.GetFuelCost(crew)
+			}
+			if err := matrix.row(planet, costs); err != nil {
+				return err
+			}
+			if written++; written%matrixFlushRows == 0 {
+				return matrix.flush()
+			}
+			return nil
+		})
+		if err == nil && !started {
+			err = start()
+		}
+		if err == nil {
+			err = matrix.close()
+		}
+		if err != nil {
+			if !started {
+				problems.Abort(context, err)
+				return
+			}
+			slog.WarnContext(context.Request.Context(), "fuel matrix stream failed", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// parseCrewRange reads crew sizes given as a single size ("5") or an inclusive range ("1..20") walked by step.
+func parseCrewRange(crew string, step string) ([]int64, error) {
+	invalid := problems.BadRequest(problems.InvalidQuery, `Crew should be a positive size or a range such as "1..20", and step a positive size.`)
+
+	first, last, isRange := strings.Cut(crew, "..")
+	from, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || from <= 0 {
+		return nil, invalid
+	}
+	to := from
+	if isRange {
+		if to, err = strconv.ParseInt(last, 10, 64); err != nil || to < from {
+			return nil, invalid
+		}
+	}
+	increment, err := strconv.ParseInt(step, 10, 64)
+	if err != nil || increment <= 0 {
+		return nil, invalid
+	}
+
+	// walking past the last size must not overflow
+	if to > math.MaxInt64-increment {
+		return nil, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Crew sizes should be at most %d with a step of %d.", int64(math.MaxInt64)-increment, increment))
+	}
+	count := (to-from)/increment + 1
+	if count > maxMatrixCrews {
+		return nil, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("A fuel matrix has at most %d crew sizes.", maxMatrixCrews))
+	}
+	crews := make([]int64, count)
+	for i := range crews {
+		crews[i] = from + int64(i)*increment
+	}
+	return crews, nil
+}
+
+// matrixWriter writes a fuel matrix in one format: the header once, then a row per planet.
+type matrixWriter interface {
+	contentType() string
+	header(crews []int64) error
+	row(planet models.Planet, costs []float64) error
+	flush() error
+	close() error
+}
+
+// flusher is the http.ResponseWriter of gin, which can push buffered rows to the client.
+type flusher interface {
+	io.Writer
+	http.Flusher
+}
+
+func newMatrixWriter(format string, writer flusher) (matrixWriter, error) {
+	switch format {
+	case "json":
+		return &jsonMatrix{writer: writer}, nil
+	case "csv":
+		return &csvMatrix{writer: writer, csv: csv.NewWriter(writer)}, nil
+	case "table":
+		return &tableMatrix{writer: writer, table: tabwriter.NewWriter(writer, tableCellWidth, 0, 2, ' ', tabwriter.AlignRight)}, nil
+	}
+	return nil, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Unknown format %s, use json, csv or table.", format))
+}
+
+// jsonMatrix writes {"status": 200, "crew": [...], "data": [{"planetId": ..., "name": ..., "fuelCosts": [...]}, ...]}.
+type jsonMatrix struct {
+	writer flusher
+	rows   int
+}
+
+type matrixRow struct {
+	PlanetID  uint      `json:"planetId"`
+	Name      string    `json:"name"`
+	FuelCosts []float64 `json:"fuelCosts"`
+}
+
+func (m *jsonMatrix) contentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (m *jsonMatrix) header(crews []int64) error {
+	encoded, err := json.Marshal(crews)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(m.writer, `{"status":%d,"crew":%s,"data":[`, http.StatusOK, encoded)
+	return err
+}
+
+func (m *jsonMatrix) row(planet models.Planet, costs []float64) error {
+	encoded, err := json.Marshal(matrixRow{PlanetID: planet.ID, Name: planet.Name, FuelCosts: costs})
+	if err != nil {
+		return err
+	}
+	if m.rows > 0 {
+		encoded = append([]byte{','}, encoded...)
+	}
+	m.rows++
+	_, err = m.writer.Write(encoded)
+	return err
+}
+
+func (m *jsonMatrix) flush() error {
+	m.writer.Flush()
+	return nil
+}
+
+func (m *jsonMatrix) close() error {
+	_, err := io.WriteString(m.writer, "]}")
+	return err
+}
+
+// csvMatrix writes a planet_id,name,crew_1,crew_2,... header then a line per planet.
+type csvMatrix struct {
+	writer flusher
+	csv    *csv.Writer
+}
+
+func (m *csvMatrix) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (m *csvMatrix) header(crews []int64) error {
+	record := []string{"planet_id", "name"}
+	for _, crew := range crews {
+		record = append(record, "crew_"+strconv.FormatInt(crew, 10))
+	}
+	return m.csv.Write(record)
+}
+
+func (m *csvMatrix) row(planet models.Planet, costs []float64) error {
+	record := []string{strconv.FormatUint(uint64(planet.ID), 10), planet.Name}
+	for _, cost := range costs {
+		record = append(record, strconv.FormatFloat(cost, 'f', -1, 64))
+	}
+	return m.csv.Write(record)
+}
+
+func (m *csvMatrix) flush() error {
+	m.csv.Flush()
+	m.writer.Flush()
+	return m.csv.Error()
+}
+
+func (m *csvMatrix) close() error {
+	return m.flush()
+}
+
+// tableMatrix writes right aligned columns with costs rounded to two decimals.
+type tableMatrix struct {
+	writer flusher
+	table  *tabwriter.Writer
+}
+
+func (m *tableMatrix) contentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (m *tableMatrix) header(crews []int64) error {
+	cells := []string{"ID", "PLANET"}
+	for _, crew := range crews {
+		cells = append(cells, "CREW "+strconv.FormatInt(crew, 10))
+	}
+	_, err := io.WriteString(m.table, strings.Join(cells, "\t")+"\t\n")
+	return err
+}
+
+func (m *tableMatrix) row(planet models.Planet, costs []float64) error {
+	cells := []string{strconv.FormatUint(uint64(planet.ID), 10), planet.Name}
+	for _, cost := range costs {
+		cells = append(cells, strconv.FormatFloat(cost, 'f', 2, 64))
+	}
+	_, err := io.WriteString(m.table, strings.Join(cells, "\t")+"\t\n")
+	return err
+}
+
+func (m *tableMatrix) flush() error {
+	if err := m.table.Flush(); err != nil {
+		return err
+	}
+	m.writer.Flush()
+	return nil
+}
+
+func (m *tableMatrix) close() error {
+	return m.flush()
+}
@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuelMatrix(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", saturn)
+
+	get := func(query url.Values) (*http.Response, string) {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + "/fuel-matrix?" + query.Encode())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	resp, body := get(url.Values{"crew": {"1..5"}, "step": {"2"}, "filter[type]": {`{"eq": "terrestrial"}`}, "sort": {"name"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"status": 200, "crew": [1, 3, 5], "data": [{"planetId": 2, "name": "Pluto", "fuelCosts": [200, 600, 1000]}]}`, body)
+
+	resp, body = get(url.Values{"filter[surface_gravity]": {`{"gt": 0}`}, "sort": {"density desc"}, "limit": {"1"}, "page": {"1"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `"fuelCosts"`)
+
+	resp, body = get(url.Values{"crew": {"2..3"}, "format": {"csv"}, "sort": {"distance desc"}, "page": {"1"}, "limit": {"2"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "planet_id,name,crew_2,crew_3\n3,Saturn,2949120,4423680\n2,Pluto,400,600\n", body)
+
+	resp, body = get(url.Values{"crew": {"10"}, "format": {"table"}, "sort": {"name"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, strings.Join([]string{
+		"            ID        PLANET       CREW 10",
+		"             1       Jupiter    5248800.00",
+		"             2         Pluto       2000.00",
+		"             3        Saturn   14745600.00",
+		"",
+	}, "\n"), body)
+
+	resp, body = get(url.Values{"filter[name]": {`{"eq": "Vulcan"}`}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"status": 200, "crew": [1], "data": []}`, body)
+
+	errorTests := []url.Values{
+		{"crew": {"0..4"}},
+		{"crew": {"5..1"}},
+		{"crew": {"1..5"}, "step": {"0"}},
+		{"crew": {"1..2000"}},
+		{"crew": {"9223372036854775807"}},
+		{"crew": {"9223372036854775800..9223372036854775806"}, "step": {"5"}},
+		{"format": {"xml"}},
+		{"sort": {"fuel"}},
+	}
+	for _, query := range errorTests {
+		resp, _ := get(query)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query.Encode())
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"), query.Encode())
+	}
+}
+
+func TestFuelMatrixStreamsLargeCatalogues(t *testing.T) {
+	server := setupEventServer(t)
+	for i := 0; i < 250; i++ {
+		send(t, server, "POST", "/planets", gin.H{"name": fmt.Sprintf("Planet %03d", i), "description": "A generated planet", "distance": 11 + i, "radius": 2, "mass": 2, "type": "terrestrial"})
+	}
+
+	resp, err := server.Client().Get(server.URL + "/fuel-matrix?crew=1..3&format=csv")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 251)
+	assert.Equal(t, []string{"250", "Planet 249", "1040", "2080", "3120"}, records[250])
+
+	resp, err = server.Client().Get(server.URL + "/fuel-matrix?crew=1..3&format=table")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	table, _ := io.ReadAll(resp.Body)
+	lines := strings.Split(strings.TrimSuffix(string(table), "\n"), "\n")
+	assert.Len(t, lines, 251)
+	for _, line := range lines {
+		assert.Len(t, line, len(lines[0]), "rows flushed in separate blocks stay aligned")
+	}
+
+	resp, err = server.Client().Get(server.URL + "/fuel-matrix?crew=1..3")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var matrix struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&matrix))
+	assert.Len(t, matrix.Data, 250)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"gorm.io/gorm"
+)
+
+func HealthzHandler() gin.HandlerFunc {
+	// healthz reports that the process is alive and serving requests.
+	return func (context *gin.Context) {
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "ok"})
+	}
+}
+
+func ReadyzHandler(db *gorm.DB) gin.HandlerFunc {
+	// readyz reports whether the database is reachable and its schema is up to date.
+	return func (context *gin.Context) {
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.PingContext(context.Request.Context())
+		}
+		if err != nil {
+			problems.Abort(context, problems.New(http.StatusServiceUnavailable, problems.NotReady, "Database is unreachable."))
+			return
+		}
+
+		if err := database.CheckMigrations(db.WithContext(context.Request.Context())); err != nil {
+			problems.Abort(context, problems.New(http.StatusServiceUnavailable, problems.NotReady, "Database migrations are not current: "+err.Error()))
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "ready"})
+	}
+}
@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestHealthAndReadiness(t *testing.T) {
+
+	router, msg, sqlDB, err := setupDBandRouter()
+	if err != nil {
+		t.Errorf(msg, err)
+	}
+	if sqlDB != nil {
+		defer sqlDB.Close()
+	}
+
+	tests := []struct {
+		endpoint string
+		expectedStatus int
+		expectedMessage string
+	}{
+		{"/healthz", http.StatusOK, "ok"},
+		{"/readyz", http.StatusOK, "ready"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", test.endpoint, nil)
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, test.expectedStatus, w.Code)
+		var response struct {
+			Message string `json:"message"`
+		}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		assert.Equal(t, test.expectedMessage, response.Message)
+	}
+}
+
+func TestReadinessFailures(t *testing.T) {
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	// keep the single connection so the unmigrated in-memory database stays the same one
+	sqlDB.SetMaxOpenConns(1)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router, db)
+
+	// schema has not been migrated yet
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// database connection is gone
+	sqlDB.Close()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
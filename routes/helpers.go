@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+)
+
+// includesDerived reads include=derived, asking for the derived properties of planets. No other parts can be included.
+func includesDerived(context *gin.Context) (bool, error) {
+	include := context.Query("include")
+	if include == "" {
+		return false, nil
+	}
+	for _, part := range strings.Split(include, ",") {
+		if part != "derived" {
+			return false, problems.BadRequest(problems.InvalidQuery, "Unknown include "+part+", use derived.")
+		}
+	}
+	return true, nil
+}
+
+// parseID reads the named path parameter as a positive integer id.
+func parseID(context *gin.Context, param string, entity string) (int64, error) {
+	id, err := strconv.ParseInt(context.Param(param), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, problems.BadRequest(problems.InvalidID, "Could not parse "+entity+" id.")
+	}
+	return id, nil
+}
+
+// bindJSON binds the request body, reporting malformed JSON as a bad request and failed field rules as unprocessable.
+func bindJSON(context *gin.Context, target interface{}) error {
+	err := context.ShouldBindJSON(target)
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+	return problems.BadRequest(problems.InvalidBody, "Could not parse request data.")
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+// ItineraryRequest lists the planets to visit, the crew and the optional constraints of an itinerary.
+type ItineraryRequest struct {
+	PlanetIDs      []int64 `binding:"required" json:"planetIds"`
+	Crew           int64   `binding:"required" json:"crew"`
+	MustVisitOrder []int64 `json:"mustVisitOrder"`
+	MaxStops       int     `json:"maxStops"`
+}
+
+func PlanItineraryHandler(db *gorm.DB) gin.HandlerFunc {
+	// planItinerary returns the cheapest order to visit the requested planets, with the fuel cost of every leg.
+	itineraryService := services.NewItineraries(db)
+	return func (context *gin.Context) {
+		var request ItineraryRequest
+		if err := bindJSON(context, &request); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		itinerary, err := itineraryService.Plan(context.Request.Context(), request.PlanetIDs, request.Crew, request.MustVisitOrder, request.MaxStops)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": itinerary})
+	}
+}
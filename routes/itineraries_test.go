@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanItinerary(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", saturn)
+
+	legs := func(body map[string]interface{}) ([]string, []interface{}) {
+		var planets []string
+		var from []interface{}
+		for _, leg := range body["data"].(map[string]interface{})["legs"].([]interface{}) {
+			leg := leg.(map[string]interface{})
+			planets = append(planets, leg["planet"].(map[string]interface{})["name"].(string))
+			from = append(from, leg["fromPlanetId"])
+		}
+		return planets, from
+	}
+
+	tests := []struct {
+		name             string
+		body             gin.H
+		expectedOrder    []string
+		expectedFrom     []interface{}
+		expectedFuelCost float64
+	}{
+		{
+			"cheapest order",
+			gin.H{"planetIds": []int{3, 2, 1}, "crew": 1},
+			[]string{"Jupiter", "Pluto", "Saturn"},
+			[]interface{}{nil, float64(1), float64(2)},
+			524880 + 120 + 655360,
+		},
+		{
+			"required visiting order",
+			gin.H{"planetIds": []int{3, 2, 1}, "crew": 2, "mustVisitOrder": []int{2, 1}},
+			[]string{"Pluto", "Jupiter", "Saturn"},
+			[]interface{}{nil, float64(2), float64(1)},
+			2 * (200 + 787320 + 1146880),
+		},
+	}
+	for _, test := range tests {
+		status, body := request(t, server, "POST", "/itineraries/plan", test.body)
+		assert.Equal(t, http.StatusOK, status, test.name)
+		order, from := legs(body)
+		assert.Equal(t, test.expectedOrder, order, test.name)
+		assert.Equal(t, test.expectedFrom, from, test.name)
+		assert.Equal(t, test.expectedFuelCost, body["data"].(map[string]interface{})["totalFuelCost"], test.name)
+		assert.Equal(t, true, body["data"].(map[string]interface{})["exact"], test.name)
+	}
+
+	validationTests := []struct {
+		body            gin.H
+		expectedMessage string
+	}{
+		{gin.H{"planetIds": []int{1, 2}, "crew": -1}, "Crew capacity should be positive."},
+		{gin.H{"planetIds": []int{1, 1}, "crew": 1}, "Planet 1 is listed more than once."},
+		{gin.H{"planetIds": []int{1, 2, 3}, "crew": 1, "maxStops": 2}, "Itinerary visits 3 planets, more than the maximum of 2 stops."},
+		{gin.H{"planetIds": []int{1, 2}, "crew": 1, "mustVisitOrder": []int{3}}, "Planet 3 of the visiting order is not in the itinerary."},
+		{gin.H{"planetIds": []int{1, 7}, "crew": 1}, "Could not find planet 7."},
+		{gin.H{"crew": 1}, "Could not parse request data."},
+	}
+	for _, test := range validationTests {
+		status, body := request(t, server, "POST", "/itineraries/plan", test.body)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, test.expectedMessage, body["detail"])
+	}
+}
@@ -0,0 +1,175 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+// parseMoonIDs reads the planet and moon ids of the /planets/:id/moons/:moonId routes.
+func parseMoonIDs(context *gin.Context) (int64, int64, error) {
+	planetId, err := parseID(context, "id", "planet")
+	if err != nil {
+		return 0, 0, err
+	}
+	moonId, err := parseID(context, "moonId", "moon")
+	if err != nil {
+		return 0, 0, err
+	}
+	return planetId, moonId, nil
+}
+
+func GetMoonsHandler(db *gorm.DB) gin.HandlerFunc {
+	// getMoons retrieves the moons of a planet matching the query.
+	moonService := services.NewMoons(db)
+	return func (context *gin.Context) {
+		planetId, err := parseID(context, "id", "planet")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		moons, err := moonService.List(context.Request.Context(), planetId, &params)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": moons, "total": len(moons), "page": params.Page, "limit": params.Limit})
+	}
+}
+
+func GetMoonHandler(db *gorm.DB) gin.HandlerFunc {
+	// getMoon retrieves a moon of a planet by its ID.
+	moonService := services.NewMoons(db)
+	return func (context *gin.Context) {
+		planetId, moonId, err := parseMoonIDs(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		moon, err := moonService.Get(context.Request.Context(), planetId, moonId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": moon})
+	}
+}
+
+func CreateMoonHandler(db *gorm.DB) gin.HandlerFunc {
+	// createMoon creates a new moon of a planet from the JSON request body.
+	moonService := services.NewMoons(db)
+	return func (context *gin.Context) {
+		planetId, err := parseID(context, "id", "planet")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var moon models.Moon
+		if err := bindJSON(context, &moon); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := moonService.Create(context.Request.Context(), planetId, &moon); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Moon created!", "moon": moon})
+	}
+}
+
+func UpdateMoonHandler(db *gorm.DB) gin.HandlerFunc {
+	// updateMoon updates the details of a moon of a planet.
+	moonService := services.NewMoons(db)
+	return func (context *gin.Context) {
+		planetId, moonId, err := parseMoonIDs(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := moonService.Get(context.Request.Context(), planetId, moonId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var updatedMoon models.Moon
+		if err := bindJSON(context, &updatedMoon); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := moonService.Update(context.Request.Context(), planetId, moonId, updatedMoon); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Moon updated successfully!"})
+	}
+}
+
+func DeleteMoonHandler(db *gorm.DB) gin.HandlerFunc {
+	// deleteMoon deletes a moon of a planet.
+	moonService := services.NewMoons(db)
+	return func (context *gin.Context) {
+		planetId, moonId, err := parseMoonIDs(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := moonService.Delete(context.Request.Context(), planetId, moonId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Moon deleted successfully!"})
+	}
+}
+
+func GetMoonFuelCostHandler(db *gorm.DB) gin.HandlerFunc {
+	// getMoonFuelCost estimates the fuel needed to take the crew to the planet and down to its moon.
+	moonService := services.NewMoons(db)
+	return func (context *gin.Context) {
+		planetId, moonId, err := parseMoonIDs(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := moonService.Get(context.Request.Context(), planetId, moonId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var crew Crew
+		if err := bindJSON(context, &crew); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		fuelCost, err := moonService.FuelCost(context.Request.Context(), planetId, moonId, crew.Capacity)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": fuelCost})
+	}
+}
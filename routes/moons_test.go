@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoons(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", jupiter)
+	charon := gin.H{"name": "Charon", "radius": 1, "mass": 1, "orbitalDistance": 16}
+
+	validationTests := []struct {
+		body            gin.H
+		expectedMessage string
+	}{
+		{gin.H{"name": "Charon", "radius": 3, "mass": 1, "orbitalDistance": 16}, "Radius should be positive and smaller than the radius of the planet."},
+		{gin.H{"name": "Charon", "radius": 1, "mass": 4, "orbitalDistance": 16}, "Mass should be positive and smaller than the mass of the planet."},
+		{gin.H{"name": "Charon", "radius": 1, "mass": 1, "orbitalDistance": 0.5}, "Orbital distance should be more than one radius of the planet."},
+		{gin.H{"name": "Charon", "radius": 1, "mass": 1}, "Could not parse request data."},
+	}
+	for _, test := range validationTests {
+		status, body := request(t, server, "POST", "/planets/1/moons", test.body)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, test.expectedMessage, body["detail"])
+	}
+
+	status, body := request(t, server, "POST", "/planets/1/moons", charon)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, float64(1), body["moon"].(map[string]interface{})["planetId"])
+	status, body = request(t, server, "POST", "/planets/1/moons", charon)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "Planet 1 already has a moon named Charon.", body["detail"])
+	send(t, server, "POST", "/planets/2/moons", gin.H{"name": "Io", "radius": 1, "mass": 1, "orbitalDistance": 5.9})
+	send(t, server, "POST", "/planets/1/moons", gin.H{"name": "Nix", "radius": 0.1, "mass": 0.1, "orbitalDistance": 41})
+
+	status, body = request(t, server, "GET", "/planets/1/moons?sort=orbital_distance+desc", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Nix", "Charon"}, names(body))
+
+	status, body = request(t, server, "GET", "/planets/1/moons/2", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Could not find moon 2 of planet 1.", body["detail"])
+	status, _ = request(t, server, "GET", "/planets/9/moons", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = request(t, server, "PUT", "/planets/1/moons/1", gin.H{"name": "Charon", "radius": 1, "mass": 1.5, "orbitalDistance": 16})
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request(t, server, "GET", "/planets/1/moons/1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1.5, body["data"].(map[string]interface{})["mass"])
+
+	// 200 per crew member to Pluto, then 16 / (1.5 / 1^2)^2 per crew member down to Charon
+	status, body = request(t, server, "GET", "/planets/1/moons/1/fuelCost", gin.H{"Capacity": 9})
+	assert.Equal(t, http.StatusOK, status)
+	assert.InDelta(t, 1800+64, body["data"], 1e-9)
+	status, _ = request(t, server, "GET", "/planets/1/moons/1/fuelCost", gin.H{"Capacity": -1})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	status, _ = request(t, server, "DELETE", "/planets/1/moons/3", nil)
+	assert.Equal(t, http.StatusOK, status)
+	send(t, server, "DELETE", "/planets/1", nil)
+	status, _ = request(t, server, "GET", "/planets/1/moons/1", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, body = request(t, server, "GET", "/planets/2/moons", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Io"}, names(body))
+}
@@ -0,0 +1,106 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+
+	router, msg, sqlDB, err := setupDBandRouter()
+	if err != nil {
+		t.Errorf(msg, err)
+	}
+	if sqlDB != nil {
+		defer sqlDB.Close()
+	}
+
+	var registered []openapi.Route
+	for _, route := range router.Routes() {
+		// the documentation UI itself is not part of the API
+		if strings.HasPrefix(route.Path, "/docs") {
+			continue
+		}
+		registered = append(registered, openapi.Route{Method: route.Method, Path: route.Path})
+	}
+	sort.Slice(registered, func(i, j int) bool {
+		if registered[i].Path == registered[j].Path {
+			return registered[i].Method < registered[j].Method
+		}
+		return registered[i].Path < registered[j].Path
+	})
+
+	assert.Equal(t, openapi.Routes(), registered, "routes registered in RegisterRoutes and the OpenAPI document have drifted apart")
+
+	// every templated path segment must be declared as a path parameter
+	pathParameter := regexp.MustCompile(`\{([^}]+)\}`)
+	for path, operations := range openapi.Build().Paths {
+		for method, operation := range operations {
+			for _, match := range pathParameter.FindAllStringSubmatch(path, -1) {
+				declared := false
+				for _, parameter := range operation.Parameters {
+					declared = declared || (parameter.In == "path" && parameter.Name == match[1])
+				}
+				assert.True(t, declared, "%s %s does not declare path parameter %s", method, path, match[1])
+			}
+		}
+	}
+}
+
+func TestOpenAPIEndpoints(t *testing.T) {
+
+	router, msg, sqlDB, err := setupDBandRouter()
+	if err != nil {
+		t.Errorf(msg, err)
+	}
+	if sqlDB != nil {
+		defer sqlDB.Close()
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/openapi.json", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var document struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `json:"properties"`
+				Required   []string               `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+		t.Fatalf("Failed to unmarshal document: %v", err)
+	}
+	assert.Equal(t, "3.1.0", document.OpenAPI)
+	assert.Contains(t, document.Paths, "/planets/{id}")
+	assert.ElementsMatch(t, []string{"name", "description", "distance", "radius", "type"}, document.Components.Schemas["Planet"].Required)
+	assert.Contains(t, document.Components.Schemas["FilterParam"].Properties, "notin")
+	assert.Contains(t, document.Components.Schemas["Problem"].Properties, "code")
+
+	tests := []struct {
+		endpoint string
+		expectedContent string
+	}{
+		{"/docs", "swagger-ui"},
+		{"/docs/assets/initializer.js", "/openapi.json"},
+		{"/docs/assets/swagger-ui-bundle.js", "SwaggerUIBundle"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", test.endpoint, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, test.endpoint)
+		assert.Contains(t, w.Body.String(), test.expectedContent, test.endpoint)
+	}
+}
@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+func GetPlanetTypesHandler(db *gorm.DB) gin.HandlerFunc {
+	// getPlanetTypes lists the built-in and stored planet types with their ranges and gravity models.
+	typeService := services.NewPlanetTypes(db)
+	return func (context *gin.Context) {
+		kinds, err := typeService.List(context.Request.Context())
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": kinds, "total": len(kinds)})
+	}
+}
+
+func GetPlanetTypeHandler(db *gorm.DB) gin.HandlerFunc {
+	// getPlanetType retrieves a planet type by its name.
+	typeService := services.NewPlanetTypes(db)
+	return func (context *gin.Context) {
+		kind, err := typeService.Get(context.Request.Context(), context.Param("name"))
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": kind})
+	}
+}
+
+func CreatePlanetTypeHandler(db *gorm.DB) gin.HandlerFunc {
+	// createPlanetType stores a new planet type from the JSON request body, planets may use it right away.
+	typeService := services.NewPlanetTypes(db)
+	return func (context *gin.Context) {
+		var kind models.PlanetTypeDefinition
+		if err := bindJSON(context, &kind); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := typeService.Create(context.Request.Context(), &kind); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Planet type created!", "planetType": kind})
+	}
+}
+
+func UpdatePlanetTypeHandler(db *gorm.DB) gin.HandlerFunc {
+	// updatePlanetType replaces the definition of a stored planet type, built-in types cannot be changed.
+	typeService := services.NewPlanetTypes(db)
+	return func (context *gin.Context) {
+		if _, err := typeService.Get(context.Request.Context(), context.Param("name")); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var updatedKind models.PlanetTypeDefinition
+		if err := bindJSON(context, &updatedKind); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		kind, err := typeService.Update(context.Request.Context(), context.Param("name"), updatedKind)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Planet type updated successfully!", "planetType": kind})
+	}
+}
+
+func DeletePlanetTypeHandler(db *gorm.DB) gin.HandlerFunc {
+	// deletePlanetType deletes a stored planet type that no planet uses.
+	typeService := services.NewPlanetTypes(db)
+	return func (context *gin.Context) {
+		if err := typeService.Delete(context.Request.Context(), context.Param("name")); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Planet type deleted successfully!"})
+	}
+}
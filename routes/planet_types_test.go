@@ -0,0 +1,141 @@
+package routes
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPlanetTypes(t *testing.T) {
+	server := setupEventServer(t)
+
+	status, body := request(t, server, "GET", "/planet-types", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"terrestrial", "gas_giant", "ice_giant", "super_earth", "dwarf", "ocean_world"}, names(body))
+
+	hycean := gin.H{"name": "hycean", "description": "An ocean world under a hydrogen atmosphere", "minRadius": 1, "maxRadius": 3,
+		"minMass": 1, "maxMass": 10, "gravityModel": "fixed_mass", "gravityMass": 2}
+	validationTests := []struct {
+		body            gin.H
+		status          int
+		expectedMessage string
+	}{
+		{gin.H{"name": "Hycean", "maxRadius": 3, "maxMass": 10, "gravityModel": "mass"}, http.StatusUnprocessableEntity, "Name should be up to 32 lower case letters, digits and underscores, starting with a letter."},
+		{gin.H{"name": "hycean", "minRadius": 3, "maxRadius": 1, "maxMass": 10, "gravityModel": "mass"}, http.StatusUnprocessableEntity, "Radius range should not be negative and minRadius should be smaller than maxRadius."},
+		{gin.H{"name": "hycean", "maxRadius": 3, "maxMass": 10, "gravityModel": "tidal"}, http.StatusUnprocessableEntity, "Gravity model should be mass or fixed_mass."},
+		{gin.H{"name": "hycean", "maxRadius": 3, "maxMass": 10, "gravityModel": "fixed_mass"}, http.StatusUnprocessableEntity, "Gravity mass should be positive with the fixed_mass gravity model."},
+		{gin.H{"name": "gas_giant", "maxRadius": 3, "maxMass": 10, "gravityModel": "mass"}, http.StatusConflict, "A planet type named gas_giant already exists."},
+	}
+	for _, test := range validationTests {
+		status, body := request(t, server, "POST", "/planet-types", test.body)
+		assert.Equal(t, test.status, status)
+		assert.Equal(t, test.expectedMessage, body["detail"])
+	}
+
+	status, body = request(t, server, "POST", "/planet-types", hycean)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, false, body["planetType"].(map[string]interface{})["builtIn"])
+	status, body = request(t, server, "GET", "/planet-types/hycean", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2.0, body["data"].(map[string]interface{})["gravityMass"])
+	status, _ = request(t, server, "GET", "/planet-types/chthonian", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// each type validates its own ranges
+	send(t, server, "POST", "/planets", gin.H{"name": "Neptune", "description": "An ice giant", "distance": 11, "radius": 3.9, "mass": 17, "type": "ice_giant"})
+	status, body = request(t, server, "POST", "/planets", gin.H{"name": "Kepler-10b", "description": "A super-Earth", "distance": 600, "radius": 3, "mass": 3, "type": "super_earth"})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "Radius should be between 1 and 2 Earth radii.", body["detail"])
+
+	status, body = request(t, server, "POST", "/planets", gin.H{"name": "K2-18b", "description": "A hycean world", "distance": 124, "radius": 2.6, "mass": 8.6, "type": "hycean"})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, 8.6, body["planet"].(map[string]interface{})["mass"])
+
+	// the fixed gravity mass of the type, in Go and in SQL alike
+	gravity := 2 / math.Pow(2.6, 2)
+	fuelCost := 124 / math.Pow(gravity, 2) * 4
+	status, body = request(t, server, "GET", "/planets/getFuelCost/2", gin.H{"Capacity": 4})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, fuelCost, body["data"])
+	breakdown := body["breakdown"].(map[string]interface{})
+	assert.Equal(t, "2 / radius^2 with radius 2.6, hyceans use a fixed mass of 2 instead of their mass of 8.6", breakdown["gravityDerivation"])
+	assert.Empty(t, breakdown["warnings"])
+
+	priced := func(condition string) []string {
+		query := url.Values{"crew": {"4"}, "filter[fuel_cost]": {`{"` + condition + `": ` + strconv.FormatFloat(fuelCost, 'g', -1, 64) + `}`}, "filter[type]": {`{"eq": "hycean"}`}}
+		_, body := request(t, server, "GET", "/planets?"+query.Encode(), nil)
+		return names(body)
+	}
+	assert.Equal(t, []string{"K2-18b"}, priced("lte"))
+	assert.Empty(t, priced("lt"))
+
+	// built-in types cannot change, stored ones apply their new definition right away
+	status, _ = request(t, server, "PUT", "/planet-types/gas_giant", hycean)
+	assert.Equal(t, http.StatusConflict, status)
+	status, _ = request(t, server, "DELETE", "/planet-types/terrestrial", nil)
+	assert.Equal(t, http.StatusConflict, status)
+	hycean["gravityMass"] = 4
+	status, body = request(t, server, "PUT", "/planet-types/hycean", hycean)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 4.0, body["planetType"].(map[string]interface{})["gravityMass"])
+	_, body = request(t, server, "GET", "/planets/getFuelCost/2", gin.H{"Capacity": 4})
+	assert.Equal(t, fuelCost/4, body["data"])
+
+	status, body = request(t, server, "DELETE", "/planet-types/hycean", nil)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "Planet type hycean is still used by 1 planets, change or delete them first.", body["detail"])
+	send(t, server, "DELETE", "/planets/2", nil)
+	status, _ = request(t, server, "DELETE", "/planet-types/hycean", nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = request(t, server, "GET", "/planet-types/hycean", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestPlanetTypesAcrossServers(t *testing.T) {
+	// two servers sharing a database, each with its own cache of the stored types
+	servers := make([]*httptest.Server, 2)
+	for i := range servers {
+		db, err := gorm.Open(database.Open("file:planet_types?mode=memory&cache=shared"), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open shared in-memory database: %v", err)
+		}
+		sqlDB, _ := db.DB()
+		sqlDB.SetMaxOpenConns(1)
+		if err = db.AutoMigrate(database.Models...); err != nil {
+			t.Fatalf("Failed to migrate: %v", err)
+		}
+		router := gin.New()
+		RegisterRoutes(router, db)
+		servers[i] = httptest.NewServer(router)
+		t.Cleanup(func() {
+			servers[i].Close()
+			sqlDB.Close()
+		})
+	}
+	first, second := servers[0], servers[1]
+
+	status, _ := request(t, second, "GET", "/planet-types/chthonian", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	send(t, first, "POST", "/planet-types", gin.H{"name": "chthonian", "maxRadius": 2, "maxMass": 10, "gravityModel": "fixed_mass", "gravityMass": 1})
+	status, _ = request(t, second, "POST", "/planets", gin.H{"name": "CoRoT-7b", "description": "A stripped core", "distance": 500, "radius": 1.5, "mass": 5, "type": "chthonian"})
+	assert.Equal(t, http.StatusCreated, status)
+	_, body := request(t, second, "GET", "/planets/getFuelCost/1", gin.H{"Capacity": 1})
+	assert.Equal(t, 500/math.Pow(1/math.Pow(1.5, 2), 2), body["data"])
+
+	send(t, first, "PUT", "/planet-types/chthonian", gin.H{"maxRadius": 2, "maxMass": 10, "gravityModel": "mass"})
+	_, body = request(t, second, "GET", "/planets/getFuelCost/1", gin.H{"Capacity": 1})
+	assert.Equal(t, 500/math.Pow(5/math.Pow(1.5, 2), 2), body["data"])
+
+	send(t, second, "DELETE", "/planets/1", nil)
+	send(t, second, "DELETE", "/planet-types/chthonian", nil)
+	status, _ = request(t, first, "GET", "/planet-types/chthonian", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
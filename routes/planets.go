@@ -3,171 +3,237 @@ package routes
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
 	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
 	"gorm.io/gorm"
 )
 
 func GetPlanetsHandler(db *gorm.DB) gin.HandlerFunc {
 	// getPlanets retrieves all the planets and returns them as a JSON response.
+	planetService := services.NewPlanets(db)
 	return func (context *gin.Context) {
-		oldDB := db
 		var params queryoperations.QueryParams
 		if err := params.BindQuery(context); err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
 			return
 		}
-		
-		db = queryoperations.Apply(db, &params, &models.PlanetFilters)
-		
-		var planets []models.Planet
-		result := db.Find(&planets)
 
-		if result.Error != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": "Could not fetch planets. Try again later."})
+		derived, err := includesDerived(context)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
-		
-		// restoring old db connection object without filters, sorting, pagination for future API calls
-		db = oldDB
-		
-		context.JSON(http.StatusOK, gin.H{
-			"status": http.StatusOK, 
-			"data": planets,
-			"total": result.RowsAffected,
-			"page":  params.Page,
-			"limit": params.Limit,
-		})
-	}
-}
-
-func GetPlanetHandler(db *gorm.DB) gin.HandlerFunc {
-	// getPlanet retrieves a planet by its ID and returns it as JSON response.
-	return func (context *gin.Context) {
-		planetId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+		requested, err := parseUnits(context)
 		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse planet id."})
+			problems.Abort(context, err)
 			return
 		}
 
-		var planet models.Planet
-		result := db.Find(&planet, planetId)
+		// with a crew every planet is priced, and fuel_cost can be filtered and sorted on
+		var planets interface{}
+		var count int64
+		var listed []*models.Planet
+		if crew := context.Query("crew"); crew != "" {
+			crewCapacity, err := strconv.ParseInt(crew, 10, 64)
+			if err != nil {
+				problems.Abort(context, problems.BadRequest(problems.InvalidQuery, "Could not parse crew."))
+				return
+			}
+			priced, err := planetService.Priced(context.Request.Context(), &params, crewCapacity)
+			if err != nil {
+				problems.Abort(context, err)
+				return
+			}
+			total, err := planetService.CountPriced(context.Request.Context(), &params, crewCapacity)
+			if err != nil {
+				problems.Abort(context, err)
+				return
+			}
+			planets, count = priced, total
+			for i := range priced {
+				listed = append(listed, &priced[i].Planet)
+			}
+		} else {
+			_, filtered := params.Filters["fuel_cost"]
+			sorted := false
+			for _, field := range params.SortFields() {
+				sorted = sorted || field == "fuel_cost"
+			}
+			if filtered || sorted {
+				problems.Abort(context, problems.BadRequest(problems.InvalidQuery, "Filtering or sorting on fuel_cost requires crew."))
+				return
+			}
+			found, err := planetService.List(context.Request.Context(), &params)
+			if err != nil {
+				problems.Abort(context, err)
+				return
+			}
+			total, err := planetService.Count(context.Request.Context(), &params)
+			if err != nil {
+				problems.Abort(context, err)
+				return
+			}
+			planets, count = found, total
+			for i := range found {
+				listed = append(listed, &found[i])
+			}
+		}
 
-		if result.Error != nil || planet.ID == 0 {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not fetch planet."})
-			return
+		if derived {
+			if err := planetService.Derive(context.Request.Context(), listed...); err != nil {
+				problems.Abort(context, err)
+				return
+			}
 		}
 
-		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": planet})
+		respondPlanets(context, gin.H{
+			"status": http.StatusOK, 
+			"data": planets,
+			"total": count,
+			"page":  params.Page,
+			"limit": params.Limit,
+		}, requested)
 	}
 }
 
-func CreatePlanetHandler(db *gorm.DB) gin.HandlerFunc {
-	// createPlanet creates a new planet based on the JSON data provided in the request body.
-	// It binds the JSON data to the planet model, saves it to the database, and returns the created planet as a JSON response.
-	return func (context *gin.Context) {
-		var planet models.Planet
-		err := context.ShouldBindJSON(&planet)
+// Budget is the crew and fuel budget of a reachability query.
+type Budget struct {
+	Crew   int64    `form:"crew" binding:"required"`
+	Budget *float64 `form:"budget" binding:"required"`
+}
 
-		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse request data."})
+func GetReachablePlanetsHandler(db *gorm.DB) gin.HandlerFunc {
+	// getReachablePlanets retrieves the planets a crew can reach within a fuel budget, cheapest first.
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		var budget Budget
+		if err := context.ShouldBindQuery(&budget); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, "Could not parse crew and budget."))
 			return
 		}
 
-		// future scope: add unique name check
-
-		if planet.Type == models.GasGiant {
-			planet.Mass = 5
-		}
-
-		if !(10 < planet.Distance && planet.Distance < 1000) {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Distance should be between 10 and 1000."})
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
 			return
 		}
 
-		if !(0.1 < planet.Radius && planet.Radius < 10) {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Radius should be between 0.1 and 10."})
+		derived, err := includesDerived(context)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
-
-		if !(0.1 < planet.Mass && planet.Mass < 10) {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Mass should be between 0.1 and 10."})
+		requested, err := parseUnits(context)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
-		if planet.Type != models.GasGiant && planet.Type != models.Terrestrial {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Invalid planet type."})
+		planets, err := planetService.Reachable(context.Request.Context(), &params, budget.Crew, *budget.Budget)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
-
-		result := db.Create(&planet)
-
-		if result.Error != nil || planet.ID == 0  {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not create planet. Try again later."})
+		total, err := planetService.CountReachable(context.Request.Context(), &params, budget.Crew, *budget.Budget)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
+		if derived {
+			listed := make([]*models.Planet, len(planets))
+			for i := range planets {
+				listed[i] = &planets[i].Planet
+			}
+			if err := planetService.Derive(context.Request.Context(), listed...); err != nil {
+				problems.Abort(context, err)
+				return
+			}
+		}
 
-		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Planet created!", "planet": planet})
+		respondPlanets(context, gin.H{"status": http.StatusOK, "data": planets, "total": total, "page": params.Page, "limit": params.Limit}, requested)
 	}
 }
 
-func UpdatePlanetHandler(db *gorm.DB) gin.HandlerFunc {
-	// updatePlanet updates the details of a planet based on the provided ID.
+func GetPlanetHandler(db *gorm.DB) gin.HandlerFunc {
+	// getPlanet retrieves a planet by its ID and returns it as JSON response.
+	planetService := services.NewPlanets(db)
 	return func (context *gin.Context) {
-		planetId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+		planetId, err := parseID(context, "id", "planet")
 		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse planet id."})
+			problems.Abort(context, err)
 			return
 		}
 
-		var planet models.Planet
-		result := db.Find(&planet, planetId)
-
-		if result.Error != nil || planet.ID == 0 {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not fetch planet for given id."})
+		derived, err := includesDerived(context)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
-
-		var updatedPlanet models.Planet
-		err = context.ShouldBindJSON(&updatedPlanet)
-
+		requested, err := parseUnits(context)
 		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse request data."})
+			problems.Abort(context, err)
 			return
 		}
 
-		// future scope: add unique name check
-
-		if updatedPlanet.Type == models.GasGiant {
-			updatedPlanet.Mass = 5
+		planet, err := planetService.Get(context.Request.Context(), planetId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		if derived {
+			if err := planetService.Derive(context.Request.Context(), &planet); err != nil {
+				problems.Abort(context, err)
+				return
+			}
 		}
 
-		if !(10 < updatedPlanet.Distance && updatedPlanet.Distance < 1000) {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Distance should be between 10 and 1000."})
+		respondPlanets(context, gin.H{"status": http.StatusOK, "data": planet}, requested)
+	}
+}
+
+func CreatePlanetHandler(db *gorm.DB) gin.HandlerFunc {
+	// createPlanet creates a new planet based on the JSON data provided in the request body.
+	// It binds the JSON data to the planet model, saves it to the database, and returns the created planet as a JSON response.
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		var planet models.Planet
+		if err := bindPlanet(context, &planet); err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
-		if !(0.1 < updatedPlanet.Radius && updatedPlanet.Radius < 10) {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Radius should be between 0.1 and 10."})
+		if err := planetService.Create(context.Request.Context(), &planet); err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
-		if !(0.1 < updatedPlanet.Mass && updatedPlanet.Mass < 10) {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Mass should be between 0.1 and 10."})
+		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Planet created!", "planet": planet})
+	}
+}
+
+func UpdatePlanetHandler(db *gorm.DB) gin.HandlerFunc {
+	// updatePlanet updates the details of a planet based on the provided ID.
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		planetId, err := parseID(context, "id", "planet")
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
-		if updatedPlanet.Type != models.GasGiant && updatedPlanet.Type != models.Terrestrial {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Invalid planet type."})
+		var updatedPlanet models.Planet
+		if err := bindPlanet(context, &updatedPlanet); err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
-		updatedPlanet.ID = uint(planetId)
-		result = db.Model(&planet).Updates(updatedPlanet)
-		if result.Error != nil || planet.ID == 0  {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not update planet."})
+		if _, err := planetService.Replace(context.Request.Context(), planetId, updatedPlanet); err != nil {
+			problems.Abort(context, err)
 			return
 		}
 		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Planet updated successfully!"})
@@ -176,25 +242,16 @@ func UpdatePlanetHandler(db *gorm.DB) gin.HandlerFunc {
 
 func DeletePlanetHandler(db *gorm.DB) gin.HandlerFunc {
 	// deletePlanet deletes a planet based on the provided planet ID.
+	planetService := services.NewPlanets(db)
 	return func (context *gin.Context) {
-		planetId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+		planetId, err := parseID(context, "id", "planet")
 		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse planet id."})
+			problems.Abort(context, err)
 			return
 		}
 
-		var planet models.Planet
-		result := db.Find(&planet, planetId)
-
-		if result.Error != nil || planet.ID == 0 {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not fetch planet for given id."})
-			return
-		}
-
-		result = db.Delete(&models.Planet{}, planetId)
-
-		if result.Error != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not delete the planet."})
+		if err := planetService.Delete(context.Request.Context(), planetId); err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
@@ -208,31 +265,80 @@ type Crew struct {
 
 func GetFuelCostHandler(db *gorm.DB) gin.HandlerFunc {
 	// Function to retrieve an overall fuel cost estimation for a trip to any particular exoplanet for given crew capacity.
+	planetService := services.NewPlanets(db)
 	return func (context *gin.Context) {
-		planetId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+		planetId, err := parseID(context, "id", "planet")
 		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse planet id."})
+			problems.Abort(context, err)
 			return
 		}
 
-		var planet models.Planet
-		result := db.Find(&planet, planetId)
-
-		if result.Error != nil || planet.ID == 0 {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not fetch planet for given id."})
+		planet, err := planetService.Get(context.Request.Context(), planetId)
+		if err != nil {
+			problems.Abort(context, err)
 			return
 		}
 
 		var crew Crew
-		err = context.ShouldBindJSON(&crew)
+		if err := bindJSON(context, &crew); err != nil {
+			problems.Abort(context, err)
+			return
+		}
 
+		breakdown, err := planetService.ExplainFuelCost(planet, crew.Capacity)
 		if err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": "Could not parse request data."})
+			problems.Abort(context, err)
+			return
+		}
+		response := gin.H{"status": http.StatusOK, "data": breakdown.FuelCost, "breakdown": breakdown}
+
+		switch mode := context.DefaultQuery("mode", "exact"); mode {
+		case "exact":
+		case "montecarlo":
+			options, err := parseMonteCarlo(context)
+			if err != nil {
+				problems.Abort(context, err)
+				return
+			}
+			estimate, err := planetService.EstimateFuelCost(planet, crew.Capacity, options)
+			if err != nil {
+				problems.Abort(context, err)
+				return
+			}
+			response["estimate"] = estimate
+		default:
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, "Unknown mode "+mode+", use exact or montecarlo."))
 			return
 		}
 
-		fuelCost := planet.GetFuelCost(crew.Capacity)
+		context.JSON(http.StatusOK, response)
+	}
+}
 
-		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": fuelCost})
+// MonteCarloQuery is the sampling of a montecarlo fuel cost, percentiles are comma separated.
+type MonteCarloQuery struct {
+	Samples     int    `form:"samples,default=10000"`
+	Seed        int64  `form:"seed,default=1"`
+	Percentiles string `form:"percentiles"`
+}
+
+// parseMonteCarlo reads the sampling options of a montecarlo fuel cost, the 5th, 50th and 95th percentiles by default.
+func parseMonteCarlo(context *gin.Context) (models.MonteCarlo, error) {
+	invalid := problems.BadRequest(problems.InvalidQuery, "Could not parse samples, seed and percentiles.")
+	var query MonteCarloQuery
+	if err := context.ShouldBindQuery(&query); err != nil {
+		return models.MonteCarlo{}, invalid
 	}
-}
\ No newline at end of file
+	options := models.MonteCarlo{Samples: query.Samples, Seed: query.Seed, Percentiles: []float64{5, 50, 95}}
+	if query.Percentiles != "" {
+		options.Percentiles = nil
+		for _, text := range strings.Split(query.Percentiles, ",") {
+			percentile, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+			if err != nil {
+				return models.MonteCarlo{}, invalid
+			}
+			options.Percentiles = append(options.Percentiles, percentile)
+		}
+	}
+	return options, nil
+}
@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
 	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/physics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -40,7 +44,7 @@ func seedTestDB(db *gorm.DB) error {
 }
 
 func setupDBandRouter() (*gin.Engine, string, *sql.DB, error){
-	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	db, err := gorm.Open(database.Open("file::memory:?cache=shared"), &gorm.Config{})
 	if err != nil {
         return nil, "Failed to open in-memory database: %v", nil, err
     }
@@ -48,7 +52,7 @@ func setupDBandRouter() (*gin.Engine, string, *sql.DB, error){
 	sqlDB, _ := db.DB()
 
 	// Migrate the schema
-	err = db.AutoMigrate(&models.Planet{})
+	err = db.AutoMigrate(database.Models...)
 	if err != nil {
         return nil, "Failed to migrate User model: %v", sqlDB, err
     }
@@ -93,7 +97,7 @@ func TestGetPlanets(t *testing.T) {
 		{`/planets?filter[type]={"in": ["gas_giant", "terrestrial"]}`, http.StatusOK, "Jupiter", 2},
 		{`/planets?filter[type]={"notin": ["gas_giant", "terrestrial"]}`, http.StatusOK, "", 0},
 		{`/planets?filter[type]={"like": 1}`, http.StatusBadRequest, "Jupiter", 0},
-		{`/planets?page=1&limit=1`, http.StatusOK, "Jupiter", 1},
+		{`/planets?page=1&limit=1`, http.StatusOK, "Jupiter", 2},
 		{`/planets?page=abc&limit=abc`, http.StatusBadRequest, "", 0},
 	}
 
@@ -124,6 +128,101 @@ func TestGetPlanets(t *testing.T) {
 
 }
 
+func TestGetPlanetsDoesNotLeakQueryState(t *testing.T) {
+
+	router, msg, sqlDB, err := setupDBandRouter()
+	if err != nil {
+		t.Errorf(msg, err)
+	}
+	if sqlDB != nil {
+		defer sqlDB.Close()
+	}
+
+	tests := []struct {
+		endpoint string
+		expectedStatus int
+		expectedTotal int
+	}{
+		// a failing query must not leave its filters or sorting behind for later requests
+		{`/planets?filter[type]={"eq": "gas_giant"}&sort=unknown_column`, http.StatusBadRequest, 0},
+		{"/planets", http.StatusOK, 2},
+		{`/planets?filter[type]={"eq": "terrestrial"}`, http.StatusOK, 1},
+		{"/planets", http.StatusOK, 2},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", test.endpoint, nil)
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, test.expectedStatus, w.Code, test.endpoint)
+		var response struct {
+			Data  []models.Planet `json:"data"`
+			Total int `json:"total"`
+		}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		assert.Equal(t, test.expectedTotal, response.Total, test.endpoint)
+	}
+}
+
+func TestGetPlanetsConcurrent(t *testing.T) {
+
+	router, msg, sqlDB, err := setupDBandRouter()
+	if err != nil {
+		t.Errorf(msg, err)
+	}
+	if sqlDB != nil {
+		defer sqlDB.Close()
+		// the shared in-memory database locks tables across connections, serialise access to it
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	tests := []struct {
+		endpoint string
+		expectedName1 string
+		expectedTotal int
+	}{
+		{"/planets", "Jupiter", 2},
+		{"/planets?sort=radius", "Pluto", 2},
+		{`/planets?filter[type]={"eq": "gas_giant"}`, "Jupiter", 1},
+		{`/planets?filter[type]={"eq": "terrestrial"}`, "Pluto", 1},
+		{`/planets?filter[radius]={"gt": 8}`, "Jupiter", 1},
+		{`/planets?page=2&limit=1`, "Pluto", 2},
+		{`/planets?sort=unknown_column`, "", 0},
+	}
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				test := tests[(worker+i)%len(tests)]
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", test.endpoint, nil)
+
+				router.ServeHTTP(w, req)
+				var response struct {
+					Data  []models.Planet `json:"data"`
+					Total int `json:"total"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Errorf("Failed to unmarshal response: %v", err)
+					return
+				}
+				if len(response.Data) > 0 {
+					assert.Equal(t, test.expectedName1, response.Data[0].Name, test.endpoint)
+				}
+				assert.Equal(t, test.expectedTotal, response.Total, test.endpoint)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestGetPlanet(t *testing.T) {
 
 	router, msg, sqlDB, err := setupDBandRouter()
@@ -141,7 +240,7 @@ func TestGetPlanet(t *testing.T) {
 		expectedErrorMessage string
 	}{
 		{"/planets/1", http.StatusOK, "Jupiter", ""},
-		{"/planets/3", http.StatusBadRequest, "", "Could not fetch planet."},
+		{"/planets/3", http.StatusNotFound, "", "Could not find planet 3."},
 		{"/planets/abc", http.StatusBadRequest, "", "Could not parse planet id."},
 	}
 
@@ -155,7 +254,7 @@ func TestGetPlanet(t *testing.T) {
 		assert.Equal(t, test.expectedStatus, w.Code)
 		var response struct {
 			Data  models.Planet `json:"data"`
-			Message  string `json:"message"`
+			Detail  string `json:"detail"`
 			Status int    `json:"status"`
 		}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -166,7 +265,7 @@ func TestGetPlanet(t *testing.T) {
 		if response.Data.ID != 0 {
 			assert.Equal(t, test.expectedName, response.Data.Name)
 		} else {
-			assert.Equal(t, test.expectedErrorMessage, response.Message)
+			assert.Equal(t, test.expectedErrorMessage, response.Detail)
 		}
 	}
 	
@@ -203,7 +302,7 @@ func TestCreatePlanet(t *testing.T) {
 			"radius": 8,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "", "Could not parse request data."},
+		}, http.StatusUnprocessableEntity, "", "Could not parse request data."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -211,7 +310,7 @@ func TestCreatePlanet(t *testing.T) {
 			"radius": 8,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "", "Distance should be between 10 and 1000."},
+		}, http.StatusUnprocessableEntity, "", "Distance should be between 10 and 1000 light years."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -219,7 +318,7 @@ func TestCreatePlanet(t *testing.T) {
 			"radius": 20,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "", "Radius should be between 0.1 and 10."},
+		}, http.StatusUnprocessableEntity, "", "Radius should be between 0.1 and 10 Earth radii."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -227,7 +326,7 @@ func TestCreatePlanet(t *testing.T) {
 			"radius": 2,
 			"mass": 20,
 			"type": "terrestrial",
-		}, http.StatusBadRequest, "", "Mass should be between 0.1 and 10."},
+		}, http.StatusUnprocessableEntity, "", "Mass should be between 0.1 and 10 Earth masses."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -235,7 +334,15 @@ func TestCreatePlanet(t *testing.T) {
 			"radius": 2,
 			"mass": 8,
 			"type": "gas",
-		}, http.StatusBadRequest, "", "Invalid planet type."},
+		}, http.StatusUnprocessableEntity, "", "Invalid planet type."},
+		{gin.H{
+			"name": "Jupiter",
+			"description": "A second Jupiter",
+			"distance": 400,
+			"radius": 2,
+			"mass": 8,
+			"type": "terrestrial",
+		}, http.StatusConflict, "", "A planet named Jupiter already exists."},
 	}
 
 	for _, test := range tests {
@@ -252,6 +359,7 @@ func TestCreatePlanet(t *testing.T) {
 		var response struct {
 			Planet  models.Planet `json:"planet"`
 			Message  string `json:"message"`
+			Detail  string `json:"detail"`
 			Status int    `json:"status"`
 		}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -260,8 +368,11 @@ func TestCreatePlanet(t *testing.T) {
 		}
 		if test.expectedStatus == http.StatusCreated {
 			assert.Equal(t, test.expectedName, response.Planet.Name)
+			assert.Equal(t, test.expectedMessage, response.Message)
+		} else {
+			assert.Equal(t, test.expectedMessage, response.Detail)
+			assert.Equal(t, problems.ContentType, w.Header().Get("Content-Type"))
 		}
-		assert.Equal(t, test.expectedMessage, response.Message)
 	}
 
 }
@@ -297,7 +408,7 @@ func TestUpdatePlanet(t *testing.T) {
 			"radius": 8,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "/planets/3", "Could not fetch planet for given id."},
+		}, http.StatusNotFound, "/planets/3", "Could not find planet 3."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -313,7 +424,7 @@ func TestUpdatePlanet(t *testing.T) {
 			"radius": 8,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "/planets/2", "Could not parse request data."},
+		}, http.StatusUnprocessableEntity, "/planets/2", "Could not parse request data."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -321,7 +432,7 @@ func TestUpdatePlanet(t *testing.T) {
 			"radius": 8,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "/planets/2", "Distance should be between 10 and 1000."},
+		}, http.StatusUnprocessableEntity, "/planets/2", "Distance should be between 10 and 1000 light years."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -329,7 +440,7 @@ func TestUpdatePlanet(t *testing.T) {
 			"radius": 20,
 			"mass": 8,
 			"type": "gas_giant",
-		}, http.StatusBadRequest, "/planets/2", "Radius should be between 0.1 and 10."},
+		}, http.StatusUnprocessableEntity, "/planets/2", "Radius should be between 0.1 and 10 Earth radii."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -337,7 +448,7 @@ func TestUpdatePlanet(t *testing.T) {
 			"radius": 2,
 			"mass": 20,
 			"type": "terrestrial",
-		}, http.StatusBadRequest, "/planets/2", "Mass should be between 0.1 and 10."},
+		}, http.StatusUnprocessableEntity, "/planets/2", "Mass should be between 0.1 and 10 Earth masses."},
 		{gin.H{
 			"name": "Neptune",
 			"description": "The far away gassy planet",
@@ -345,7 +456,23 @@ func TestUpdatePlanet(t *testing.T) {
 			"radius": 2,
 			"mass": 8,
 			"type": "gas",
-		}, http.StatusBadRequest, "/planets/2", "Invalid planet type."},
+		}, http.StatusUnprocessableEntity, "/planets/2", "Invalid planet type."},
+		{gin.H{
+			"name": "Jupiter",
+			"description": "A second Jupiter",
+			"distance": 400,
+			"radius": 2,
+			"mass": 8,
+			"type": "terrestrial",
+		}, http.StatusConflict, "/planets/2", "A planet named Jupiter already exists."},
+		{gin.H{
+			"name": "Neptune",
+			"description": "Keeping its own name is not a conflict",
+			"distance": 400,
+			"radius": 2,
+			"mass": 8,
+			"type": "terrestrial",
+		}, http.StatusOK, "/planets/2", "Planet updated successfully!"},
 	}
 
 	for _, test := range tests {
@@ -360,13 +487,18 @@ func TestUpdatePlanet(t *testing.T) {
 		assert.Equal(t, test.expectedStatus, w.Code)
 		var response struct {
 			Message  string `json:"message"`
+			Detail  string `json:"detail"`
 			Status int    `json:"status"`
 		}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
-		assert.Equal(t, test.expectedMessage, response.Message)
+		if test.expectedStatus == http.StatusOK {
+			assert.Equal(t, test.expectedMessage, response.Message)
+		} else {
+			assert.Equal(t, test.expectedMessage, response.Detail)
+		}
 	}
 }
 
@@ -386,7 +518,7 @@ func TestDeletePlanet(t *testing.T) {
 		expectedMessage string
 	}{
 		{"/planets/2", http.StatusOK, "Planet deleted successfully!"},
-		{"/planets/3", http.StatusBadRequest, "Could not fetch planet for given id."},
+		{"/planets/3", http.StatusNotFound, "Could not find planet 3."},
 		{"/planets/abc", http.StatusBadRequest, "Could not parse planet id."},
 	}
 
@@ -401,13 +533,18 @@ func TestDeletePlanet(t *testing.T) {
 		assert.Equal(t, test.expectedStatus, w.Code)
 		var response struct {
 			Message  string `json:"message"`
+			Detail  string `json:"detail"`
 			Status int    `json:"status"`
 		}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
-		assert.Equal(t, test.expectedMessage, response.Message)
+		if test.expectedStatus == http.StatusOK {
+			assert.Equal(t, test.expectedMessage, response.Message)
+		} else {
+			assert.Equal(t, test.expectedMessage, response.Detail)
+		}
 	}
 }
 
@@ -430,8 +567,8 @@ func TestPlanetFuelCost(t *testing.T) {
 	}{
 		{"/planets/getFuelCost/1", gin.H{"Capacity": 10}, http.StatusOK, "", 5.248800000000001e+06},
 		{"/planets/getFuelCost/2", gin.H{"Capacity": 10}, http.StatusOK, "", 2000.0},
-		{"/planets/getFuelCost/2", gin.H{"cap": 10}, http.StatusBadRequest, "Could not parse request data.", 2000.0},
-		{"/planets/getFuelCost/3", gin.H{"Capacity": 10}, http.StatusBadRequest, "Could not fetch planet for given id.", 0},
+		{"/planets/getFuelCost/2", gin.H{"cap": 10}, http.StatusUnprocessableEntity, "Could not parse request data.", 2000.0},
+		{"/planets/getFuelCost/3", gin.H{"Capacity": 10}, http.StatusNotFound, "Could not find planet 3.", 0},
 		{"/planets/getFuelCost/abc", gin.H{"Capacity": 10}, http.StatusBadRequest, "Could not parse planet id.", 0},
 	}
 
@@ -448,7 +585,7 @@ func TestPlanetFuelCost(t *testing.T) {
 		assert.Equal(t, test.expectedStatus, w.Code)
 		var response struct {
 			Data  float64 `json:"data"`
-			Message  string `json:"message"`
+			Detail  string `json:"detail"`
 			Status int    `json:"status"`
 		}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -458,8 +595,147 @@ func TestPlanetFuelCost(t *testing.T) {
 		if test.expectedStatus == http.StatusOK {
 			assert.Equal(t, test.expectedData, response.Data)
 		} else {
-			assert.Equal(t, test.expectedMessage, response.Message)
+			assert.Equal(t, test.expectedMessage, response.Detail)
 		}
 	}
 
-}
\ No newline at end of file
+}
+
+func TestPlanetFuelCostBreakdown(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", gin.H{"name": "Wisp", "description": "A fluffy planet", "distance": 100, "radius": 9.9, "mass": 0.2, "type": "terrestrial"})
+	send(t, server, "POST", "/planets", gin.H{"name": "Puff", "description": "A light gas giant", "distance": 100, "radius": 1, "mass": 0.5, "type": "gas_giant"})
+
+	breakdown := func(planetId string) map[string]interface{} {
+		status, body := request(t, server, "GET", "/planets/getFuelCost/"+planetId, gin.H{"Capacity": 10})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, body["data"], body["breakdown"].(map[string]interface{})["fuelCost"])
+		return body["breakdown"].(map[string]interface{})
+	}
+
+	terrestrial := breakdown("2")
+	assert.Equal(t, "1", terrestrial["modelVersion"])
+	assert.Equal(t, 0.5, terrestrial["gravity"])
+	assert.Equal(t, "mass / radius^2 with mass 2 and radius 2", terrestrial["gravityDerivation"])
+	assert.Equal(t, float64(50), terrestrial["distance"])
+	assert.Equal(t, float64(200), terrestrial["distanceFactor"])
+	assert.Equal(t, float64(10), terrestrial["crewMultiplier"])
+	assert.Equal(t, 2000.0, terrestrial["fuelCost"])
+	assert.Empty(t, terrestrial["warnings"])
+
+	gasGiant := breakdown("1")
+	assert.Equal(t, "0.5 / radius^2 with radius 9, gas giants use a fixed mass of 0.5 instead of their mass of 8", gasGiant["gravityDerivation"])
+	assert.Empty(t, gasGiant["warnings"], "a fixed mass is part of the model, not a warning")
+	assert.Equal(t, "0.5 / radius^2 with radius 1, gas giants use a fixed mass of 0.5", breakdown("4")["gravityDerivation"])
+
+	lowGravity := breakdown("3")
+	assert.Len(t, lowGravity["warnings"], 1)
+	assert.Contains(t, lowGravity["warnings"].([]interface{})[0], "is close to zero")
+}
+
+func TestPlanetFuelCostEstimate(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", gin.H{"name": "Errant", "description": "A poorly measured planet", "distance": 100, "radius": 2, "mass": 2, "type": "terrestrial",
+		"distanceError": 5, "radiusError": 0.1, "massError": 0.1})
+
+	estimate := func(path string) map[string]interface{} {
+		t.Helper()
+		status, body := request(t, server, "GET", path, gin.H{"Capacity": 10})
+		assert.Equal(t, http.StatusOK, status)
+		return body["estimate"].(map[string]interface{})
+	}
+
+	_, body := request(t, server, "GET", "/planets/getFuelCost/1", gin.H{"Capacity": 10})
+	assert.NotContains(t, body, "estimate")
+
+	exact := estimate("/planets/getFuelCost/1?mode=montecarlo&samples=100")
+	assert.Equal(t, map[string]interface{}{
+		"samples": 100.0, "seed": 1.0, "mean": 2000.0, "stdDev": 0.0,
+		"percentiles": map[string]interface{}{"5": 2000.0, "50": 2000.0, "95": 2000.0},
+	}, exact, "planets without measurement errors have an exact fuel cost")
+
+	sampled := estimate("/planets/getFuelCost/2?mode=montecarlo&seed=7")
+	assert.Equal(t, sampled, estimate("/planets/getFuelCost/2?mode=montecarlo&seed=7"), "equal seeds give equal estimates")
+	assert.NotEqual(t, sampled, estimate("/planets/getFuelCost/2?mode=montecarlo&seed=8"))
+	assert.Equal(t, 10000.0, sampled["samples"])
+	assert.InDelta(t, 4000, sampled["mean"], 200)
+	assert.Greater(t, sampled["stdDev"], 0.0)
+	percentiles := sampled["percentiles"].(map[string]interface{})
+	assert.Less(t, percentiles["5"], percentiles["50"])
+	assert.Less(t, percentiles["50"], percentiles["95"])
+	assert.InDelta(t, 4000, percentiles["50"], 200)
+
+	custom := estimate("/planets/getFuelCost/2?mode=montecarlo&samples=500&percentiles=2.5,97.5")
+	assert.Len(t, custom["percentiles"], 2)
+	assert.Contains(t, custom["percentiles"], "2.5")
+
+	for _, query := range []string{"mode=bogus", "mode=montecarlo&samples=0", "mode=montecarlo&samples=100001", "mode=montecarlo&seed=x", "mode=montecarlo&percentiles=101", "mode=montecarlo&percentiles=5,x"} {
+		status, _ := request(t, server, "GET", "/planets/getFuelCost/2?"+query, gin.H{"Capacity": 10})
+		assert.Equal(t, http.StatusBadRequest, status, query)
+	}
+
+	status, body := request(t, server, "POST", "/planets", gin.H{"name": "Vague", "description": "A planet", "distance": 100, "radius": 2, "mass": 2, "type": "terrestrial", "massError": -1})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "Mass error should not be negative.", body["detail"])
+	status, body = request(t, server, "POST", "/planets", gin.H{"name": "Vague", "description": "A planet", "distance": 100, "radius": 2, "mass": 2, "type": "terrestrial", "radiusError": 1e300})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "Radius error should not exceed the radius.", body["detail"])
+}
+
+func TestDerivedProperties(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/stars", gin.H{"name": "Sol", "spectralType": "G", "mass": 1, "luminosity": 1})
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", gin.H{"name": "Terra", "description": "A familiar planet", "distance": 100, "radius": 1, "mass": 1, "type": "terrestrial", "starId": 1, "semiMajorAxis": 1})
+
+	_, body := request(t, server, "GET", "/planets/2", nil)
+	assert.NotContains(t, body["data"], "derived")
+
+	_, body = request(t, server, "GET", "/planets/2?include=derived", nil)
+	assert.Equal(t, map[string]interface{}{
+		"surfaceGravity":         physics.SurfaceGravity(2, 2),
+		"escapeVelocity":         physics.EscapeVelocity(2, 2),
+		"density":                physics.Density(2, 2),
+		"equilibriumTemperature": nil,
+	}, body["data"].(map[string]interface{})["derived"], "planets without a star have no equilibrium temperature")
+
+	_, body = request(t, server, "GET", "/planets/3?include=derived", nil)
+	derived := body["data"].(map[string]interface{})["derived"].(map[string]interface{})
+	assert.InDelta(t, 9.82, derived["surfaceGravity"], 0.01)
+	assert.Equal(t, physics.EquilibriumTemperature(1, 1), derived["equilibriumTemperature"])
+
+	list := func(query string) []string {
+		t.Helper()
+		status, body := request(t, server, "GET", "/planets?"+query, nil)
+		assert.Equal(t, http.StatusOK, status, query)
+		return names(body)
+	}
+	assert.Equal(t, []string{"Pluto", "Terra"}, list(`filter[surface_gravity]={"gte":1}&sort=name`))
+	assert.Equal(t, []string{"Pluto", "Terra", "Jupiter"}, list("sort=escape_velocity+desc,name"))
+	assert.Equal(t, []string{"Jupiter"}, list(`filter[density]={"lt":0.1}`))
+	assert.Equal(t, []string{"Terra"}, list(`filter[equilibrium_temperature]={"lt":300}`))
+	assert.Equal(t, []string{"Pluto"}, list(fmt.Sprintf(`filter[surface_gravity]={"eq":%v}`, physics.SurfaceGravity(2, 2))), "filters agree exactly with responses")
+	assert.Equal(t, []string{"Terra"}, list(`crew=1&filter[density]={"gt":5}`))
+
+	_, body = request(t, server, "GET", "/planets?include=derived&crew=2&sort=name", nil)
+	for _, planet := range body["data"].([]interface{}) {
+		assert.Contains(t, planet, "derived")
+		assert.Contains(t, planet, "fuel_cost")
+	}
+	_, body = request(t, server, "GET", "/stars/1/planets?include=derived", nil)
+	assert.Equal(t, physics.EquilibriumTemperature(1, 1), body["data"].([]interface{})[0].(map[string]interface{})["derived"].(map[string]interface{})["equilibriumTemperature"])
+	_, body = request(t, server, "GET", "/planets/reachable?crew=1&budget=1000&include=derived", nil)
+	assert.Contains(t, body["data"].([]interface{})[0], "derived")
+
+	for _, path := range []string{"/planets?include=moons", "/planets/1?include=derived,stars", "/planets/reachable?crew=1&budget=1&include=x"} {
+		status, _ := request(t, server, "GET", path, nil)
+		assert.Equal(t, http.StatusBadRequest, status, path)
+	}
+	status, body := request(t, server, "POST", "/planets", gin.H{"name": "Inside", "description": "A planet", "distance": 100, "radius": 1, "mass": 1, "type": "terrestrial", "semiMajorAxis": 0})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "Semi-major axis should be positive.", body["detail"])
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReachablePlanets(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", saturn)
+
+	// for a crew of 2: Pluto costs 400, Jupiter 1049760 and Saturn 2949120
+	tests := []struct {
+		name          string
+		query         url.Values
+		expectedNames []string
+		expectedTotal float64
+	}{
+		{"within budget, cheapest first", url.Values{"crew": {"2"}, "budget": {"2000000"}}, []string{"Pluto", "Jupiter"}, 2},
+		{"everything", url.Values{"crew": {"2"}, "budget": {"1e9"}}, []string{"Pluto", "Jupiter", "Saturn"}, 3},
+		{"with filters", url.Values{"crew": {"2"}, "budget": {"1e9"}, "filter[type]": {`{"eq": "gas_giant"}`}}, []string{"Jupiter", "Saturn"}, 2},
+		{"paginated", url.Values{"crew": {"2"}, "budget": {"1e9"}, "page": {"2"}, "limit": {"2"}}, []string{"Saturn"}, 3},
+		{"past the last page", url.Values{"crew": {"2"}, "budget": {"1e9"}, "page": {"3"}, "limit": {"2"}}, nil, 3},
+		{"nothing affordable", url.Values{"crew": {"2"}, "budget": {"0"}}, nil, 0},
+	}
+	for _, test := range tests {
+		status, body := request(t, server, "GET", "/planets/reachable?"+test.query.Encode(), nil)
+		assert.Equal(t, http.StatusOK, status, test.name)
+		assert.Equal(t, test.expectedNames, names(body), test.name)
+		assert.Equal(t, test.expectedTotal, body["total"], test.name)
+	}
+
+	status, body := request(t, server, "GET", "/planets/reachable?crew=2&budget=1000", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(400), body["data"].([]interface{})[0].(map[string]interface{})["fuel_cost"])
+
+	errorTests := []struct {
+		query          string
+		expectedStatus int
+	}{
+		{"budget=100", http.StatusBadRequest},
+		{"crew=two&budget=100", http.StatusBadRequest},
+		{"crew=-1&budget=100", http.StatusUnprocessableEntity},
+		{"crew=1&budget=-5", http.StatusUnprocessableEntity},
+		{"crew=1&budget=100&sort=fuel", http.StatusBadRequest},
+	}
+	for _, test := range errorTests {
+		status, _ := request(t, server, "GET", "/planets/reachable?"+test.query, nil)
+		assert.Equal(t, test.expectedStatus, status, test.query)
+	}
+}
+
+func TestPricedPlanets(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+	send(t, server, "POST", "/planets", saturn)
+
+	fuelCosts := func(body map[string]interface{}) []interface{} {
+		var found []interface{}
+		for _, item := range body["data"].([]interface{}) {
+			found = append(found, item.(map[string]interface{})["fuel_cost"])
+		}
+		return found
+	}
+
+	tests := []struct {
+		name              string
+		query             url.Values
+		expectedNames     []string
+		expectedFuelCosts []interface{}
+		expectedTotal     float64
+	}{
+		{"priced", url.Values{"crew": {"2"}, "sort": {"name"}}, []string{"Jupiter", "Pluto", "Saturn"}, []interface{}{1049760.0, 400.0, 2949120.0}, 3},
+		{"sorted by fuel cost", url.Values{"crew": {"2"}, "sort": {"fuel_cost desc"}}, []string{"Saturn", "Jupiter", "Pluto"}, []interface{}{2949120.0, 1049760.0, 400.0}, 3},
+		{"filtered on fuel cost", url.Values{"crew": {"1"}, "filter[fuel_cost]": {`{"gt": 200, "lte": 524881}`}}, []string{"Jupiter"}, []interface{}{524880.0}, 1},
+		{"paginated after sorting", url.Values{"crew": {"2"}, "sort": {"fuel_cost"}, "page": {"2"}, "limit": {"2"}}, []string{"Saturn"}, []interface{}{2949120.0}, 3},
+		{"combined with other filters", url.Values{"crew": {"2"}, "sort": {"fuel_cost"}, "filter[type]": {`{"eq": "gas_giant"}`}}, []string{"Jupiter", "Saturn"}, []interface{}{1049760.0, 2949120.0}, 2},
+		{"without crew", url.Values{"sort": {"name"}}, []string{"Jupiter", "Pluto", "Saturn"}, []interface{}{nil, nil, nil}, 3},
+	}
+	for _, test := range tests {
+		status, body := request(t, server, "GET", "/planets?"+test.query.Encode(), nil)
+		assert.Equal(t, http.StatusOK, status, test.name)
+		assert.Equal(t, test.expectedNames, names(body), test.name)
+		assert.Equal(t, test.expectedTotal, body["total"], test.name)
+		actualFuelCosts := fuelCosts(body)
+		assert.Len(t, actualFuelCosts, len(test.expectedFuelCosts), test.name)
+		for i, expected := range test.expectedFuelCosts {
+			if expected == nil {
+				assert.Nil(t, actualFuelCosts[i], test.name)
+			} else {
+				assert.InDelta(t, expected, actualFuelCosts[i], 1e-6, test.name)
+			}
+		}
+	}
+
+	// SQL computes the same value as GetFuelCost, so bounds at the exact cost are honoured
+	jupiterCost := models.Planet{Distance: 20, Radius: 9, Type: models.GasGiant}.GetFuelCost(3)
+	for filter, expectedNames := range map[string][]string{
+		fmt.Sprintf(`{"lte": %v}`, jupiterCost): {"Jupiter", "Pluto"},
+		fmt.Sprintf(`{"lt": %v}`, jupiterCost):  {"Pluto"},
+		fmt.Sprintf(`{"eq": %v}`, jupiterCost):  {"Jupiter"},
+	} {
+		status, body := request(t, server, "GET", "/planets?"+url.Values{"crew": {"3"}, "sort": {"name"}, "filter[fuel_cost]": {filter}}.Encode(), nil)
+		assert.Equal(t, http.StatusOK, status, filter)
+		assert.Equal(t, expectedNames, names(body), filter)
+	}
+
+	errorTests := []struct {
+		query          url.Values
+		expectedStatus int
+		expectedDetail string
+	}{
+		{url.Values{"sort": {"name, fuel_cost desc"}}, http.StatusBadRequest, "Filtering or sorting on fuel_cost requires crew."},
+		{url.Values{"sort": {"fuel_costs"}}, http.StatusBadRequest, "cannot sort by field fuel_costs"},
+		{url.Values{"filter[fuel_cost]": {`{"lte": 10}`}}, http.StatusBadRequest, "Filtering or sorting on fuel_cost requires crew."},
+		{url.Values{"crew": {"many"}}, http.StatusBadRequest, "Could not parse crew."},
+		{url.Values{"crew": {"0"}}, http.StatusUnprocessableEntity, "Crew capacity should be positive."},
+	}
+	for _, test := range errorTests {
+		status, body := request(t, server, "GET", "/planets?"+test.query.Encode(), nil)
+		assert.Equal(t, test.expectedStatus, status, test.query.Encode())
+		assert.Equal(t, test.expectedDetail, body["detail"], test.query.Encode())
+	}
+}
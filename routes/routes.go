@@ -2,15 +2,76 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/graphqlapi"
+	"github.com/kaitou-1412/Go-Space-Voyagers/metrics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/openapi"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
 	"gorm.io/gorm"
 )
 
 // RegisterRoutes registers the routes for handling requests.
 func RegisterRoutes(server *gin.Engine, db *gorm.DB) {
+	server.HandleMethodNotAllowed = true
+	server.NoRoute(problems.NoRoute())
+	server.NoMethod(problems.NoMethod())
+
+	server.GET("/healthz", HealthzHandler())
+	server.GET("/readyz", ReadyzHandler(db))
+	server.GET("/metrics", metrics.Handler())
+	server.GET("/openapi.json", openapi.SpecHandler())
+	openapi.RegisterUI(server)
+
 	server.GET("/planets", GetPlanetsHandler(db))
+	server.GET("/planets/events", PlanetEventsHandler(db))
+	server.GET("/planets/reachable", GetReachablePlanetsHandler(db))
 	server.GET("/planets/:id", GetPlanetHandler(db))
 	server.GET("/planets/getFuelCost/:id", GetFuelCostHandler(db))
 	server.POST("/planets", CreatePlanetHandler(db))
 	server.PUT("/planets/:id", UpdatePlanetHandler(db))
 	server.DELETE("/planets/:id", DeletePlanetHandler(db))
+	server.GET("/planets/:id/charts/fuel-cost", FuelCostChartHandler(db))
+
+	server.GET("/planets/:id/moons", GetMoonsHandler(db))
+	server.GET("/planets/:id/moons/:moonId", GetMoonHandler(db))
+	server.GET("/planets/:id/moons/:moonId/fuelCost", GetMoonFuelCostHandler(db))
+	server.POST("/planets/:id/moons", CreateMoonHandler(db))
+	server.PUT("/planets/:id/moons/:moonId", UpdateMoonHandler(db))
+	server.DELETE("/planets/:id/moons/:moonId", DeleteMoonHandler(db))
+
+	server.GET("/planet-types", GetPlanetTypesHandler(db))
+	server.GET("/planet-types/:name", GetPlanetTypeHandler(db))
+	server.POST("/planet-types", CreatePlanetTypeHandler(db))
+	server.PUT("/planet-types/:name", UpdatePlanetTypeHandler(db))
+	server.DELETE("/planet-types/:name", DeletePlanetTypeHandler(db))
+
+	server.GET("/fuel-matrix", FuelMatrixHandler(db))
+	server.POST("/itineraries/plan", PlanItineraryHandler(db))
+	server.GET("/charts/distance-radius", DistanceRadiusChartHandler(db))
+	server.GET("/charts/histogram", HistogramChartHandler(db))
+
+	server.GET("/stars", GetStarsHandler(db))
+	server.GET("/stars/:id", GetStarHandler(db))
+	server.GET("/stars/:id/planets", GetStarPlanetsHandler(db))
+	server.POST("/stars", CreateStarHandler(db))
+	server.PUT("/stars/:id", UpdateStarHandler(db))
+	server.DELETE("/stars/:id", DeleteStarHandler(db))
+
+	server.GET("/star-systems", GetStarSystemsHandler(db))
+	server.GET("/star-systems/:id", GetStarSystemHandler(db))
+	server.GET("/star-systems/:id/stars", GetStarSystemStarsHandler(db))
+	server.POST("/star-systems", CreateStarSystemHandler(db))
+	server.PUT("/star-systems/:id", UpdateStarSystemHandler(db))
+	server.DELETE("/star-systems/:id", DeleteStarSystemHandler(db))
+
+	server.GET("/webhooks", GetWebhooksHandler(db))
+	server.GET("/webhooks/:id", GetWebhookHandler(db))
+	server.POST("/webhooks", CreateWebhookHandler(db))
+	server.PUT("/webhooks/:id", UpdateWebhookHandler(db))
+	server.DELETE("/webhooks/:id", DeleteWebhookHandler(db))
+	server.GET("/webhooks/:id/deliveries", GetWebhookDeliveriesHandler(db))
+	server.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", RedeliverWebhookHandler(db))
+
+	graphqlHandler := graphqlapi.Handler(db, graphqlapi.DefaultLimits)
+	server.GET("/graphql", graphqlHandler)
+	server.POST("/graphql", graphqlHandler)
 }
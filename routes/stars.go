@@ -0,0 +1,300 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+func GetStarsHandler(db *gorm.DB) gin.HandlerFunc {
+	// getStars retrieves the stars matching the query and returns them as a JSON response.
+	starService := services.NewStars(db)
+	return func (context *gin.Context) {
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		stars, err := starService.List(context.Request.Context(), &params)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": stars, "total": len(stars), "page": params.Page, "limit": params.Limit})
+	}
+}
+
+func GetStarHandler(db *gorm.DB) gin.HandlerFunc {
+	// getStar retrieves a star by its ID.
+	starService := services.NewStars(db)
+	return func (context *gin.Context) {
+		starId, err := parseID(context, "id", "star")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		star, err := starService.Get(context.Request.Context(), starId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": star})
+	}
+}
+
+func GetStarPlanetsHandler(db *gorm.DB) gin.HandlerFunc {
+	// getStarPlanets retrieves the planets hosted by a star, accepting the same query as GET /planets.
+	starService := services.NewStars(db)
+	planetService := services.NewPlanets(db)
+	return func (context *gin.Context) {
+		starId, err := parseID(context, "id", "star")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		derived, err := includesDerived(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		requested, err := parseUnits(context)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		planets, err := starService.Planets(context.Request.Context(), starId, &params)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		if derived {
+			listed := make([]*models.Planet, len(planets))
+			for i := range planets {
+				listed[i] = &planets[i]
+			}
+			if err := planetService.Derive(context.Request.Context(), listed...); err != nil {
+				problems.Abort(context, err)
+				return
+			}
+		}
+
+		respondPlanets(context, gin.H{"status": http.StatusOK, "data": planets, "total": len(planets), "page": params.Page, "limit": params.Limit}, requested)
+	}
+}
+
+func CreateStarHandler(db *gorm.DB) gin.HandlerFunc {
+	// createStar creates a new star from the JSON request body.
+	starService := services.NewStars(db)
+	return func (context *gin.Context) {
+		var star models.Star
+		if err := bindJSON(context, &star); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := starService.Create(context.Request.Context(), &star); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Star created!", "star": star})
+	}
+}
+
+func UpdateStarHandler(db *gorm.DB) gin.HandlerFunc {
+	// updateStar updates the details of a star based on the provided ID.
+	starService := services.NewStars(db)
+	return func (context *gin.Context) {
+		starId, err := parseID(context, "id", "star")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := starService.Get(context.Request.Context(), starId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var updatedStar models.Star
+		if err := bindJSON(context, &updatedStar); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := starService.Update(context.Request.Context(), starId, updatedStar); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Star updated successfully!"})
+	}
+}
+
+func DeleteStarHandler(db *gorm.DB) gin.HandlerFunc {
+	// deleteStar deletes a star, ?cascade=true also deletes the planets it hosts.
+	starService := services.NewStars(db)
+	return func (context *gin.Context) {
+		starId, err := parseID(context, "id", "star")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := starService.Delete(context.Request.Context(), starId, context.Query("cascade") == "true"); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Star deleted successfully!"})
+	}
+}
+
+func GetStarSystemsHandler(db *gorm.DB) gin.HandlerFunc {
+	// getStarSystems retrieves the star systems matching the query and returns them as a JSON response.
+	systemService := services.NewStarSystems(db)
+	return func (context *gin.Context) {
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		systems, err := systemService.List(context.Request.Context(), &params)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": systems, "total": len(systems), "page": params.Page, "limit": params.Limit})
+	}
+}
+
+func GetStarSystemHandler(db *gorm.DB) gin.HandlerFunc {
+	// getStarSystem retrieves a star system by its ID.
+	systemService := services.NewStarSystems(db)
+	return func (context *gin.Context) {
+		systemId, err := parseID(context, "id", "star system")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		system, err := systemService.Get(context.Request.Context(), systemId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": system})
+	}
+}
+
+func GetStarSystemStarsHandler(db *gorm.DB) gin.HandlerFunc {
+	// getStarSystemStars retrieves the stars of a system, accepting the same query as GET /stars.
+	systemService := services.NewStarSystems(db)
+	return func (context *gin.Context) {
+		systemId, err := parseID(context, "id", "star system")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var params queryoperations.QueryParams
+		if err := params.BindQuery(context); err != nil {
+			problems.Abort(context, problems.BadRequest(problems.InvalidQuery, err.Error()))
+			return
+		}
+
+		stars, err := systemService.Stars(context.Request.Context(), systemId, &params)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": stars, "total": len(stars), "page": params.Page, "limit": params.Limit})
+	}
+}
+
+func CreateStarSystemHandler(db *gorm.DB) gin.HandlerFunc {
+	// createStarSystem creates a new star system from the JSON request body.
+	systemService := services.NewStarSystems(db)
+	return func (context *gin.Context) {
+		var system models.StarSystem
+		if err := bindJSON(context, &system); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := systemService.Create(context.Request.Context(), &system); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Star system created!", "starSystem": system})
+	}
+}
+
+func UpdateStarSystemHandler(db *gorm.DB) gin.HandlerFunc {
+	// updateStarSystem updates the details of a star system based on the provided ID.
+	systemService := services.NewStarSystems(db)
+	return func (context *gin.Context) {
+		systemId, err := parseID(context, "id", "star system")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := systemService.Get(context.Request.Context(), systemId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var updatedSystem models.StarSystem
+		if err := bindJSON(context, &updatedSystem); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := systemService.Update(context.Request.Context(), systemId, updatedSystem); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Star system updated successfully!"})
+	}
+}
+
+func DeleteStarSystemHandler(db *gorm.DB) gin.HandlerFunc {
+	// deleteStarSystem deletes a star system, its stars are kept without a system.
+	systemService := services.NewStarSystems(db)
+	return func (context *gin.Context) {
+		systemId, err := parseID(context, "id", "star system")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := systemService.Delete(context.Request.Context(), systemId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Star system deleted successfully!"})
+	}
+}
@@ -0,0 +1,152 @@
+package routes
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	sun     = gin.H{"name": "Sun", "spectralType": "G", "mass": 1, "luminosity": 1}
+	siriusA = gin.H{"name": "Sirius A", "spectralType": "A", "mass": 2.06, "luminosity": 25.4, "distance": 8.6}
+)
+
+func names(body map[string]interface{}) []string {
+	var found []string
+	for _, item := range body["data"].([]interface{}) {
+		found = append(found, item.(map[string]interface{})["name"].(string))
+	}
+	return found
+}
+
+func TestStars(t *testing.T) {
+	server := setupEventServer(t)
+
+	validationTests := []struct {
+		body            gin.H
+		expectedMessage string
+	}{
+		{gin.H{"name": "Vega", "spectralType": "Q", "mass": 2, "luminosity": 40}, "Spectral type should be one of O, B, A, F, G, K or M."},
+		{gin.H{"name": "Vega", "spectralType": "A", "mass": 400, "luminosity": 40}, "Mass should be between 0 and 300 solar masses."},
+		{gin.H{"name": "Vega", "spectralType": "A", "mass": 2, "luminosity": 40, "starSystemId": 9}, "Could not find star system 9."},
+		{gin.H{"name": "Vega", "mass": 2, "luminosity": 40}, "Could not parse request data."},
+	}
+	for _, test := range validationTests {
+		status, body := request(t, server, "POST", "/stars", test.body)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, test.expectedMessage, body["detail"])
+	}
+
+	status, body := request(t, server, "POST", "/stars", sun)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "G", body["star"].(map[string]interface{})["spectralType"])
+	status, _ = request(t, server, "POST", "/stars", sun)
+	assert.Equal(t, http.StatusConflict, status)
+	send(t, server, "POST", "/stars", siriusA)
+
+	status, _ = request(t, server, "PUT", "/stars/1", gin.H{"name": "Sol", "spectralType": "G", "mass": 1, "luminosity": 1})
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request(t, server, "GET", "/stars/1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Sol", body["data"].(map[string]interface{})["name"])
+
+	status, body = request(t, server, "GET", "/stars?sort=luminosity+desc", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Sirius A", "Sol"}, names(body))
+
+	status, body = request(t, server, "GET", "/stars/7", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Could not find star 7.", body["detail"])
+}
+
+func TestPlanetsOfStars(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/stars", sun)
+	send(t, server, "POST", "/stars", siriusA)
+
+	status, body := request(t, server, "POST", "/planets", gin.H{"name": "Vulcan", "description": "A missing planet", "distance": 20, "radius": 2, "mass": 2, "type": "terrestrial", "starId": 5})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "Could not find star 5.", body["detail"])
+
+	withStar := func(planet gin.H, starId int) gin.H {
+		hosted := gin.H{"starId": starId}
+		for key, value := range planet {
+			hosted[key] = value
+		}
+		return hosted
+	}
+	send(t, server, "POST", "/planets", withStar(jupiter, 1))
+	send(t, server, "POST", "/planets", withStar(saturn, 1))
+	send(t, server, "POST", "/planets", withStar(pluto, 2))
+
+	status, body = request(t, server, "GET", "/stars/1/planets?sort=distance+desc", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Saturn", "Jupiter"}, names(body))
+	assert.Equal(t, float64(1), body["data"].([]interface{})[0].(map[string]interface{})["starId"])
+
+	query := url.Values{"filter[star.spectral_type]": {`{"eq": "A"}`}}
+	status, body = request(t, server, "GET", "/planets?"+query.Encode(), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Pluto"}, names(body))
+
+	query = url.Values{"sort": {"star.mass desc,name"}, "filter[distance]": {`{"gte": 20}`}}
+	status, body = request(t, server, "GET", "/planets?"+query.Encode(), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Pluto", "Jupiter", "Saturn"}, names(body))
+
+	status, _ = request(t, server, "GET", "/planets/events?"+url.Values{"filter[star.mass]": {`{"gt": 1}`}}.Encode(), nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// updating a planet without a starId moves it out of its system
+	send(t, server, "PUT", "/planets/3", pluto)
+	_, body = request(t, server, "GET", "/planets/3", nil)
+	assert.NotContains(t, body["data"], "starId")
+	send(t, server, "PUT", "/planets/3", withStar(pluto, 2))
+
+	status, body = request(t, server, "DELETE", "/stars/1", nil)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "Star 1 still hosts 2 planets, delete them first or pass cascade=true.", body["detail"])
+
+	status, _ = request(t, server, "DELETE", "/stars/1?cascade=true", nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request(t, server, "GET", "/planets", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Pluto"}, names(body))
+	status, _ = request(t, server, "GET", "/stars/1", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestStarSystems(t *testing.T) {
+	server := setupEventServer(t)
+
+	status, body := request(t, server, "POST", "/star-systems", gin.H{"name": "Sirius", "description": "A binary system"})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "Sirius", body["starSystem"].(map[string]interface{})["name"])
+	status, _ = request(t, server, "POST", "/star-systems", gin.H{"name": "Sirius"})
+	assert.Equal(t, http.StatusConflict, status)
+
+	send(t, server, "POST", "/stars", gin.H{"name": "Sirius A", "spectralType": "A", "mass": 2.06, "luminosity": 25.4, "starSystemId": 1})
+	send(t, server, "POST", "/stars", gin.H{"name": "Sirius B", "spectralType": "B", "mass": 1.02, "luminosity": 0.056, "starSystemId": 1})
+	send(t, server, "POST", "/stars", sun)
+
+	status, body = request(t, server, "GET", "/star-systems/1/stars?sort=mass", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Sirius B", "Sirius A"}, names(body))
+
+	status, _ = request(t, server, "PUT", "/star-systems/1", gin.H{"name": "Alpha Canis Majoris"})
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request(t, server, "GET", "/star-systems", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"Alpha Canis Majoris"}, names(body))
+
+	status, _ = request(t, server, "DELETE", "/star-systems/1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request(t, server, "GET", "/stars/1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotContains(t, body["data"], "starSystemId")
+	status, _ = request(t, server, "GET", "/star-systems/1/stars", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
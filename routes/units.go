@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"github.com/kaitou-1412/Go-Space-Voyagers/units"
+)
+
+// bindPlanet binds a planet body whose measurements and their errors may carry a unit, such as "radius": "1.1 Rj",
+// converting them to the canonical units first.
+func bindPlanet(context *gin.Context, planet *models.Planet) error {
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(context.Request.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return problems.BadRequest(problems.InvalidBody, "Could not parse request data.")
+	}
+
+	for field, canonical := range models.PlanetUnits {
+		for _, key := range []string{field, field + "Error"} {
+			text, ok := fields[key].(string)
+			if !ok {
+				continue
+			}
+			value, err := units.Parse(text, canonical)
+			if err != nil {
+				return problems.BadRequest(problems.InvalidBody, err.Error())
+			}
+			// distances are stored in whole light years, values in between are refused rather than rounded
+			if key == "distance" {
+				whole := math.Round(value)
+				if math.Abs(value-whole) > 1e-9*math.Max(1, whole) {
+					return problems.Unprocessable(fmt.Sprintf("Distance should be a whole number of light years, %s is %.6g.", text, value))
+				}
+				value = whole
+			}
+			fields[key] = value
+		}
+	}
+
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	context.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return bindJSON(context, planet)
+}
+
+// parseUnits reads units=field:unit,... naming the units planet measurements are returned in, such as
+// "distance:pc,mass:Mj". Fields left out stay in their canonical unit.
+func parseUnits(context *gin.Context) (map[string]units.Unit, error) {
+	requested := map[string]units.Unit{}
+	if context.Query("units") == "" {
+		return requested, nil
+	}
+	for _, pair := range strings.Split(context.Query("units"), ",") {
+		field, symbol, _ := strings.Cut(pair, ":")
+		canonical, measured := models.PlanetUnits[field]
+		if !measured {
+			fields := make([]string, 0, len(models.PlanetUnits))
+			for name := range models.PlanetUnits {
+				fields = append(fields, name)
+			}
+			sort.Strings(fields)
+			return nil, problems.BadRequest(problems.InvalidQuery, "Cannot convert "+field+", use "+strings.Join(fields, ", ")+".")
+		}
+		unit, err := canonical.Lookup(symbol)
+		if err != nil {
+			return nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+		}
+		requested[field] = unit
+	}
+	return requested, nil
+}
+
+// respondPlanets writes response with the planets of its data converted to the requested units.
+func respondPlanets(context *gin.Context, response gin.H, requested map[string]units.Unit) {
+	data, symbols, err := convertUnits(response["data"], requested)
+	if err != nil {
+		problems.Abort(context, err)
+		return
+	}
+	response["data"] = data
+	if symbols != nil {
+		response["units"] = symbols
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// convertUnits returns data, a planet or a list of planets, with the measurements converted to the requested units,
+// together with the unit of every measurement. Without requested units data is returned unchanged.
+func convertUnits(data interface{}, requested map[string]units.Unit) (interface{}, map[string]string, error) {
+	if len(requested) == 0 {
+		return data, nil, nil
+	}
+	symbols := map[string]string{}
+	for field, canonical := range models.PlanetUnits {
+		symbols[field] = canonical.Symbol
+		if unit, ok := requested[field]; ok {
+			symbols[field] = unit.Symbol
+		}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, nil, err
+	}
+
+	convert := func(planet interface{}) {
+		fields, ok := planet.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for field, unit := range requested {
+			for _, key := range []string{field, field + "Error"} {
+				if value, ok := fields[key].(float64); ok {
+					fields[key] = models.PlanetUnits[field].Convert(value, unit)
+				}
+			}
+		}
+	}
+	if planets, ok := decoded.([]interface{}); ok {
+		for _, planet := range planets {
+			convert(planet)
+		}
+	} else {
+		convert(decoded)
+	}
+	return decoded, symbols, nil
+}
@@ -0,0 +1,84 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanetUnits(t *testing.T) {
+	server := setupEventServer(t)
+	send(t, server, "POST", "/planets", jupiter)
+	send(t, server, "POST", "/planets", pluto)
+
+	status, body := request(t, server, "POST", "/planets", gin.H{"name": "Proxima", "description": "A nearby planet", "type": "terrestrial",
+		"distance": "33 ly", "radius": "0.1 Rj", "radiusError": "1000 km", "mass": "0.01 Mj", "semiMajorAxis": 0.05})
+	assert.Equal(t, http.StatusCreated, status)
+	planet := body["planet"].(map[string]interface{})
+	assert.Equal(t, 33.0, planet["distance"])
+	assert.InDelta(t, 1.0973, planet["radius"], 1e-4)
+	assert.InDelta(t, 0.157, planet["radiusError"], 1e-3)
+	assert.InDelta(t, 3.1783, planet["mass"], 1e-4)
+
+	_, body = request(t, server, "GET", "/planets/3", nil)
+	assert.NotContains(t, body, "units")
+	_, body = request(t, server, "GET", "/planets/3?units=distance:pc,radius:km,semiMajorAxis:km", nil)
+	planet = body["data"].(map[string]interface{})
+	assert.InDelta(t, 10.118, planet["distance"], 1e-3)
+	assert.InDelta(t, 6991.1, planet["radius"], 0.1)
+	assert.InDelta(t, 1000, planet["radiusError"], 0.1)
+	assert.InDelta(t, 3.1783, planet["mass"], 1e-4)
+	assert.InDelta(t, 7479893.5, planet["semiMajorAxis"], 0.1)
+	assert.Equal(t, map[string]interface{}{"distance": "pc", "radius": "km", "mass": "Me", "semiMajorAxis": "km"}, body["units"])
+
+	_, body = request(t, server, "GET", "/planets?units=mass:Mj&sort=name", nil)
+	assert.Equal(t, []string{"Jupiter", "Pluto", "Proxima"}, names(body))
+	assert.InDelta(t, 0.01, body["data"].([]interface{})[2].(map[string]interface{})["mass"], 1e-9)
+
+	list := func(query string) []string {
+		t.Helper()
+		status, body := request(t, server, "GET", "/planets?sort=name&"+query, nil)
+		assert.Equal(t, http.StatusOK, status, query)
+		return names(body)
+	}
+	assert.Equal(t, []string{"Pluto", "Proxima"}, list(`filter[distance]={"gte":"10+pc"}`))
+	assert.Equal(t, []string{"Pluto"}, list(`filter[mass]={"in":["2+Me","1+Mj"]}`))
+	assert.Equal(t, []string{"Jupiter", "Pluto"}, list(`filter[radius]={"gt":"7000+km"}&crew=1`))
+	_, body = request(t, server, "GET", `/planets/reachable?crew=1&budget=1000000&filter[distance]={"lt":"10+pc"}`, nil)
+	assert.Equal(t, []string{"Jupiter"}, names(body))
+
+	status, _ = request(t, server, "PUT", "/planets/2", gin.H{"name": "Pluto", "description": "A small planet", "distance": "65 ly", "radius": 2, "mass": 2, "type": "terrestrial"})
+	assert.Equal(t, http.StatusOK, status)
+	_, body = request(t, server, "GET", "/planets/2", nil)
+	assert.Equal(t, 65.0, body["data"].(map[string]interface{})["distance"])
+
+	errorTests := []struct {
+		method string
+		path   string
+		body   interface{}
+		status int
+		detail string
+	}{
+		{"POST", "/planets", gin.H{"name": "Far", "description": "A planet", "type": "terrestrial", "distance": "2000 ly", "radius": 1, "mass": 1},
+			http.StatusUnprocessableEntity, "Distance should be between 10 and 1000 light years."},
+		{"POST", "/planets", gin.H{"name": "Near", "description": "A planet", "type": "terrestrial", "distance": "10 pc", "radius": 1, "mass": 1},
+			http.StatusUnprocessableEntity, "Distance should be a whole number of light years, 10 pc is 32.6156."},
+		{"POST", "/planets", gin.H{"name": "Near", "description": "A planet", "type": "terrestrial", "distance": "4.2 ly", "radius": 1, "mass": 1},
+			http.StatusUnprocessableEntity, "Distance should be a whole number of light years, 4.2 ly is 4.2."},
+		{"POST", "/planets", gin.H{"name": "Odd", "description": "A planet", "type": "terrestrial", "distance": "3 Mj", "radius": 1, "mass": 1},
+			http.StatusBadRequest, "Unit Mj is not a length."},
+		{"PUT", "/planets/2", gin.H{"name": "Pluto", "description": "A planet", "type": "terrestrial", "distance": 50, "radius": 1, "mass": "1 stone"},
+			http.StatusBadRequest, "Unknown unit stone."},
+		{"GET", `/planets?filter[distance]={"gte":"3+furlongs"}`, nil, http.StatusBadRequest, "Unknown unit furlongs."},
+		{"GET", "/planets?units=distance:Mj", nil, http.StatusBadRequest, "Unit Mj is not a length."},
+		{"GET", "/planets/1?units=name:pc", nil, http.StatusBadRequest, "Cannot convert name, use distance, mass, radius, semiMajorAxis."},
+		{"GET", `/planets/events?filter[radius]={"lt":"x+Re"}`, nil, http.StatusBadRequest, `Could not parse "x Re" as a length.`},
+	}
+	for _, test := range errorTests {
+		status, body := request(t, server, test.method, test.path, test.body)
+		assert.Equal(t, test.status, status, test.path)
+		assert.Equal(t, test.detail, body["detail"], test.path)
+	}
+}
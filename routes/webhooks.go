@@ -0,0 +1,169 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+// WebhookRequest is the body of webhook create and update requests, the secret is write-only.
+type WebhookRequest struct {
+	URL    string                   `json:"url"`
+	Events []models.PlanetEventType `json:"events"`
+	Secret string                   `json:"secret"`
+}
+
+func (request WebhookRequest) webhook() models.Webhook {
+	return models.Webhook{URL: request.URL, Events: request.Events, Secret: request.Secret}
+}
+
+func GetWebhooksHandler(db *gorm.DB) gin.HandlerFunc {
+	// getWebhooks retrieves all the webhook subscriptions.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		webhooks, err := webhookService.List(context.Request.Context())
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": webhooks})
+	}
+}
+
+func GetWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+	// getWebhook retrieves a webhook subscription by its ID.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		webhookId, err := parseID(context, "id", "webhook")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		webhook, err := webhookService.Get(context.Request.Context(), webhookId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": webhook})
+	}
+}
+
+func CreateWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+	// createWebhook subscribes a URL to planet events.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		var request WebhookRequest
+		if err := bindJSON(context, &request); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		webhook := request.webhook()
+		if err := webhookService.Create(context.Request.Context(), &webhook); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusCreated, gin.H{"status": http.StatusCreated, "message": "Webhook created!", "webhook": webhook})
+	}
+}
+
+func UpdateWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+	// updateWebhook replaces the URL, event types and secret of a webhook subscription.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		webhookId, err := parseID(context, "id", "webhook")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := webhookService.Get(context.Request.Context(), webhookId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		var request WebhookRequest
+		if err := bindJSON(context, &request); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if _, err := webhookService.Update(context.Request.Context(), webhookId, request.webhook()); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Webhook updated successfully!"})
+	}
+}
+
+func DeleteWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+	// deleteWebhook removes a webhook subscription and its deliveries.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		webhookId, err := parseID(context, "id", "webhook")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		if err := webhookService.Delete(context.Request.Context(), webhookId); err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Webhook deleted successfully!"})
+	}
+}
+
+func GetWebhookDeliveriesHandler(db *gorm.DB) gin.HandlerFunc {
+	// getWebhookDeliveries lists the deliveries of a webhook, ?status=dead lists its dead letters.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		webhookId, err := parseID(context, "id", "webhook")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		deliveries, err := webhookService.Deliveries(context.Request.Context(), webhookId, models.DeliveryStatus(context.Query("status")))
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "data": deliveries})
+	}
+}
+
+func RedeliverWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+	// redeliverWebhook queues a past or dead-lettered delivery for another attempt.
+	webhookService := services.NewWebhooks(db)
+	return func (context *gin.Context) {
+		webhookId, err := parseID(context, "id", "webhook")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+		deliveryId, err := parseID(context, "deliveryId", "delivery")
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		delivery, err := webhookService.Redeliver(context.Request.Context(), webhookId, deliveryId)
+		if err != nil {
+			problems.Abort(context, err)
+			return
+		}
+
+		context.JSON(http.StatusAccepted, gin.H{"status": http.StatusAccepted, "message": "Delivery queued!", "data": delivery})
+	}
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	server := setupEventServer(t)
+
+	request := func(method string, path string, body interface{}) (int, map[string]interface{}) {
+		t.Helper()
+		return request(t, server, method, path, body)
+	}
+
+	validationTests := []struct {
+		body            gin.H
+		expectedMessage string
+	}{
+		{gin.H{"url": "ftp://example.com/hook", "secret": "0123456789abcdef"}, "Webhook URL must use http or https."},
+		{gin.H{"url": "https://example.com/hook", "secret": "short"}, "Could not parse request data."},
+		{gin.H{"url": "https://example.com/hook", "secret": "0123456789abcdef", "events": []string{"exploded"}}, "Unknown event type exploded."},
+	}
+	for _, test := range validationTests {
+		status, body := request("POST", "/webhooks", test.body)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, test.expectedMessage, body["detail"])
+	}
+
+	status, body := request("POST", "/webhooks", gin.H{"url": "https://example.com/hook", "secret": "0123456789abcdef", "events": []string{"created"}})
+	assert.Equal(t, http.StatusCreated, status)
+	webhook := body["webhook"].(map[string]interface{})
+	assert.Equal(t, "https://example.com/hook", webhook["url"])
+	assert.NotContains(t, webhook, "secret")
+
+	status, _ = request("PUT", "/webhooks/1", gin.H{"url": "https://example.com/other", "secret": "fedcba9876543210"})
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request("GET", "/webhooks/1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "https://example.com/other", body["data"].(map[string]interface{})["url"])
+
+	// the planet change is queued for the webhook in the outbox
+	send(t, server, "POST", "/planets", pluto)
+	status, body = request("GET", "/webhooks/1/deliveries?status=pending", nil)
+	assert.Equal(t, http.StatusOK, status)
+	if deliveries := body["data"].([]interface{}); assert.Len(t, deliveries, 1) {
+		delivery := deliveries[0].(map[string]interface{})
+		assert.Equal(t, "pending", delivery["status"])
+		assert.Equal(t, "created", delivery["event"].(map[string]interface{})["type"])
+	}
+	status, body = request("GET", "/webhooks/1/deliveries?status=dead", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, body["data"])
+
+	status, _ = request("GET", "/webhooks/1/deliveries?status=lost", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = request("POST", "/webhooks/1/deliveries/1/redeliver", nil)
+	assert.Equal(t, http.StatusAccepted, status)
+	status, body = request("POST", "/webhooks/1/deliveries/9/redeliver", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Could not find delivery 9 of webhook 1.", body["detail"])
+
+	status, _ = request("DELETE", "/webhooks/1", nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, body = request("GET", "/webhooks", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, body["data"])
+	status, _ = request("GET", "/webhooks/1/deliveries", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
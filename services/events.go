@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"gorm.io/gorm"
+)
+
+// Events reads the persisted planet change log.
+type Events struct {
+	db *gorm.DB
+}
+
+func NewEvents(db *gorm.DB) *Events {
+	return &Events{db: db}
+}
+
+// Latest returns the id of the newest event, 0 when the log is empty.
+func (s *Events) Latest(ctx context.Context) (uint, error) {
+	var latest uint
+	err := s.db.Session(&gorm.Session{NewDB: true, Context: ctx}).Model(&models.PlanetEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&latest).Error
+	return latest, err
+}
+
+// After returns up to limit events newer than afterId, oldest first.
+func (s *Events) After(ctx context.Context, afterId uint, limit int) ([]models.PlanetEvent, error) {
+	events := []models.PlanetEvent{}
+	err := s.db.Session(&gorm.Session{NewDB: true, Context: ctx}).Where("id > ?", afterId).Order("id").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// Subscribe returns a channel signalled whenever this process records new events, and a function to unsubscribe.
+// Signals are coalesced, subscribers read the log with After to catch up.
+func (s *Events) Subscribe() (<-chan struct{}, func()) {
+	return changes.subscribe()
+}
+
+// record appends an event to the change log and queues its webhook deliveries,
+// within the transaction that changed the planet so neither is lost nor sent for a rolled back change.
+func record(tx *gorm.DB, eventType models.PlanetEventType, planet models.Planet) error {
+	event := models.PlanetEvent{Type: eventType, PlanetID: planet.ID, Planet: planet}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+
+	var webhooks []models.Webhook
+	if err := tx.Find(&webhooks).Error; err != nil {
+		return err
+	}
+	var deliveries []models.WebhookDelivery
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(eventType) {
+			deliveries = append(deliveries, models.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				EventID:       event.ID,
+				Status:        models.DeliveryPending,
+				NextAttemptAt: event.OccurredAt,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return tx.Omit("Webhook", "Event").Create(&deliveries).Error
+}
+
+// changes wakes up the subscribers of this process after events are committed.
+var changes = &notifier{subscribers: map[chan struct{}]struct{}{}}
+
+type notifier struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func (n *notifier) subscribe() (<-chan struct{}, func()) {
+	signal := make(chan struct{}, 1)
+	n.mu.Lock()
+	n.subscribers[signal] = struct{}{}
+	n.mu.Unlock()
+
+	return signal, func() {
+		n.mu.Lock()
+		delete(n.subscribers, signal)
+		n.mu.Unlock()
+	}
+}
+
+func (n *notifier) publish() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for signal := range n.subscribers {
+		select {
+		case signal <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/itinerary"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"gorm.io/gorm"
+)
+
+// MaxItineraryStops bounds the planets of one itinerary, the heuristic planner is cubic in their number.
+const MaxItineraryStops = 100
+
+// Itineraries plans trips through several planets.
+type Itineraries struct {
+	db      *gorm.DB
+	planets *Planets
+}
+
+func NewItineraries(db *gorm.DB) *Itineraries {
+	return &Itineraries{db: db, planets: NewPlanets(db)}
+}
+
+func (s *Itineraries) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// Plan finds the cheapest order to visit every planet of planetIds with a crew of crewCapacity, starting from Earth.
+// The planets of mustVisitOrder are visited in that relative order, maxStops rejects longer itineraries when positive.
+func (s *Itineraries) Plan(ctx context.Context, planetIds []int64, crewCapacity int64, mustVisitOrder []int64, maxStops int) (models.Itinerary, error) {
+	if err := validateItinerary(planetIds, crewCapacity, mustVisitOrder, maxStops); err != nil {
+		return models.Itinerary{}, err
+	}
+
+	var found []models.Planet
+	if err := s.query(ctx).Where("id IN ?", planetIds).Find(&found).Error; err != nil {
+		return models.Itinerary{}, err
+	}
+	resolved := make([]*models.Planet, len(found))
+	for i := range found {
+		resolved[i] = &found[i]
+	}
+	if err := s.planets.resolve(ctx, resolved...); err != nil {
+		return models.Itinerary{}, err
+	}
+	byId := make(map[int64]models.Planet, len(found))
+	for _, planet := range found {
+		byId[int64(planet.ID)] = planet
+	}
+
+	// stop i+1 of the solver is planetIds[i], Earth is the origin 0
+	stops := make([]models.Planet, len(planetIds)+1)
+	index := make(map[int64]int, len(planetIds))
+	for i, planetId := range planetIds {
+		planet, ok := byId[planetId]
+		if !ok {
+			return models.Itinerary{}, problems.Unprocessable(fmt.Sprintf("Could not find planet %d.", planetId))
+		}
+		stops[i+1] = planet
+		index[planetId] = i + 1
+	}
+
+	costs := make([][]float64, len(stops))
+	for from := range stops {
+		costs[from] = make([]float64, len(stops))
+		for to := 1; to < len(stops); to++ {
+			if from != to {
+				costs[from][to] = stops[to].GetFuelCostFrom(stops[from].Distance, crewCapacity)
+			}
+		}
+	}
+	sequence := make([]int, len(mustVisitOrder))
+	for i, planetId := range mustVisitOrder {
+		sequence[i] = index[planetId]
+	}
+
+	plan := itinerary.Solve(costs, sequence)
+	trip := models.Itinerary{Crew: crewCapacity, Legs: make([]models.Leg, 0, len(plan.Order)), Exact: plan.Exact}
+	previous := 0
+	for _, stop := range plan.Order {
+		leg := models.Leg{Planet: stops[stop], FuelCost: costs[previous][stop]}
+		if previous != 0 {
+			leg.FromPlanetID = &stops[previous].ID
+		}
+		trip.Legs = append(trip.Legs, leg)
+		trip.TotalFuelCost += leg.FuelCost
+		previous = stop
+	}
+	return trip, nil
+}
+
+// validateItinerary checks the request before any planet is loaded.
+func validateItinerary(planetIds []int64, crewCapacity int64, mustVisitOrder []int64, maxStops int) error {
+	if crewCapacity <= 0 {
+		return problems.Unprocessable("Crew capacity should be positive.")
+	}
+	if len(planetIds) == 0 {
+		return problems.Unprocessable("Itinerary should visit at least one planet.")
+	}
+	if len(planetIds) > MaxItineraryStops {
+		return problems.Unprocessable(fmt.Sprintf("Itinerary should visit at most %d planets.", MaxItineraryStops))
+	}
+	if maxStops < 0 {
+		return problems.Unprocessable("Maximum stops should not be negative.")
+	}
+	if maxStops > 0 && len(planetIds) > maxStops {
+		return problems.Unprocessable(fmt.Sprintf("Itinerary visits %d planets, more than the maximum of %d stops.", len(planetIds), maxStops))
+	}
+
+	listed := make(map[int64]bool, len(planetIds))
+	for _, planetId := range planetIds {
+		if listed[planetId] {
+			return problems.Unprocessable(fmt.Sprintf("Planet %d is listed more than once.", planetId))
+		}
+		listed[planetId] = true
+	}
+
+	ordered := make(map[int64]bool, len(mustVisitOrder))
+	for _, planetId := range mustVisitOrder {
+		if !listed[planetId] {
+			return problems.Unprocessable(fmt.Sprintf("Planet %d of the visiting order is not in the itinerary.", planetId))
+		}
+		if ordered[planetId] {
+			return problems.Unprocessable(fmt.Sprintf("Planet %d is listed more than once in the visiting order.", planetId))
+		}
+		ordered[planetId] = true
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"gorm.io/gorm"
+)
+
+// Moons manages the natural satellites of planets, always reached through their parent planet.
+type Moons struct {
+	db      *gorm.DB
+	planets *Planets
+}
+
+func NewMoons(db *gorm.DB) *Moons {
+	return &Moons{db: db, planets: NewPlanets(db)}
+}
+
+func (s *Moons) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// List returns the moons of a planet matching the filters, sorting and pagination of params.
+func (s *Moons) List(ctx context.Context, planetId int64, params *queryoperations.QueryParams) ([]models.Moon, error) {
+	if _, err := s.planets.Get(ctx, planetId); err != nil {
+		return nil, err
+	}
+	if err := params.ValidateSort(&models.MoonFilters); err != nil {
+		return nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+
+	moons := []models.Moon{}
+	err := queryoperations.Apply(s.query(ctx).Where("planet_id = ?", planetId), params, &models.MoonFilters).Find(&moons).Error
+	return moons, err
+}
+
+// Get loads a moon of a planet, reporting a missing planet or moon as not found.
+func (s *Moons) Get(ctx context.Context, planetId int64, moonId int64) (models.Moon, error) {
+	var moon models.Moon
+	if _, err := s.planets.Get(ctx, planetId); err != nil {
+		return moon, err
+	}
+
+	result := s.query(ctx).Where("planet_id = ?", planetId).Limit(1).Find(&moon, moonId)
+	if result.Error != nil {
+		return moon, result.Error
+	}
+	if moon.ID == 0 {
+		return moon, problems.NotFoundf("Could not find moon %d of planet %d.", moonId, planetId)
+	}
+	return moon, nil
+}
+
+// Create validates and stores a new moon of a planet.
+func (s *Moons) Create(ctx context.Context, planetId int64, moon *models.Moon) error {
+	planet, err := s.planets.Get(ctx, planetId)
+	if err != nil {
+		return err
+	}
+
+	moon.PlanetID = planet.ID
+	if err := s.validate(ctx, moon, planet, 0); err != nil {
+		return err
+	}
+	return s.query(ctx).Create(moon).Error
+}
+
+// Update validates and applies the non-zero fields of updatedMoon to a moon, which stays with its planet.
+func (s *Moons) Update(ctx context.Context, planetId int64, moonId int64, updatedMoon models.Moon) (models.Moon, error) {
+	moon, err := s.Get(ctx, planetId, moonId)
+	if err != nil {
+		return moon, err
+	}
+	planet, err := s.planets.Get(ctx, planetId)
+	if err != nil {
+		return moon, err
+	}
+
+	updatedMoon.ID = moon.ID
+	updatedMoon.PlanetID = moon.PlanetID
+	if err := s.validate(ctx, &updatedMoon, planet, moon.ID); err != nil {
+		return moon, err
+	}
+
+	err = s.query(ctx).Model(&moon).Updates(updatedMoon).Error
+	return moon, err
+}
+
+// Delete removes a moon of a planet.
+func (s *Moons) Delete(ctx context.Context, planetId int64, moonId int64) error {
+	if _, err := s.Get(ctx, planetId, moonId); err != nil {
+		return err
+	}
+	return s.query(ctx).Delete(&models.Moon{}, moonId).Error
+}
+
+// FuelCost estimates the fuel needed to take a crew of crewCapacity to a moon: the trip to its planet
+// followed by the descent from the planet to the moon.
+func (s *Moons) FuelCost(ctx context.Context, planetId int64, moonId int64, crewCapacity int64) (float64, error) {
+	moon, err := s.Get(ctx, planetId, moonId)
+	if err != nil {
+		return 0, err
+	}
+	planet, err := s.planets.Get(ctx, planetId)
+	if err != nil {
+		return 0, err
+	}
+
+	fuelCost, err := s.planets.Quote(planet, crewCapacity)
+	if err != nil {
+		return 0, err
+	}
+	return fuelCost + moon.GetDescentFuelCost(crewCapacity), nil
+}
+
+// validate checks required fields, the moon against its planet and that the planet has no other moon with the same name.
+func (s *Moons) validate(ctx context.Context, moon *models.Moon, planet models.Planet, moonId uint) error {
+	if err := binding.Validator.ValidateStruct(moon); err != nil {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+	if err := moon.Validate(planet); err != nil {
+		return problems.Unprocessable(err.Error())
+	}
+
+	var count int64
+	if err := s.query(ctx).Model(&models.Moon{}).Where("planet_id = ? AND name = ? AND id <> ?", planet.ID, moon.Name, moonId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return problems.Conflictf("Planet %d already has a moon named %s.", planet.ID, moon.Name)
+	}
+	return nil
+}
+
+// deleteMoons removes the moons of a planet inside its deletion transaction.
+func deleteMoons(tx *gorm.DB, planetId uint) error {
+	return tx.Where("planet_id = ?", planetId).Delete(&models.Moon{}).Error
+}
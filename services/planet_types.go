@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"gorm.io/gorm"
+)
+
+// PlanetTypes manages the planet types stored in the database, built-in types are not stored and cannot be changed.
+// The registry of every type is cached until the stored types change, here or in another server sharing the database.
+type PlanetTypes struct {
+	db *gorm.DB
+
+	mutex    sync.Mutex
+	version  planetTypesVersion
+	registry *models.PlanetTypeRegistry
+}
+
+// planetTypesVersion changes whenever a stored planet type is created, updated or deleted.
+type planetTypesVersion struct {
+	Stored  int64
+	Updated sql.NullString
+}
+
+func NewPlanetTypes(db *gorm.DB) *PlanetTypes {
+	return &PlanetTypes{db: db}
+}
+
+func (s *PlanetTypes) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// Registry returns the built-in and stored planet types, reading the stored ones again only after they changed.
+func (s *PlanetTypes) Registry(ctx context.Context) (*models.PlanetTypeRegistry, error) {
+	var version planetTypesVersion
+	err := s.query(ctx).Model(&models.PlanetTypeDefinition{}).Select("COUNT(*) AS stored, MAX(updated_at) AS updated").Scan(&version).Error
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.registry != nil && version == s.version {
+		return s.registry, nil
+	}
+	// a change made while loading shows in the next version, which loads them again
+	var stored []models.PlanetTypeDefinition
+	if err := s.query(ctx).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	s.registry, s.version = models.NewPlanetTypeRegistry(stored...), version
+	return s.registry, nil
+}
+
+// List returns every planet type, the built-in ones first.
+func (s *PlanetTypes) List(ctx context.Context) ([]models.PlanetTypeDefinition, error) {
+	registry, err := s.Registry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return registry.List(), nil
+}
+
+// Get returns a planet type by name, reporting an unknown type as not found.
+func (s *PlanetTypes) Get(ctx context.Context, name string) (models.PlanetTypeDefinition, error) {
+	registry, err := s.Registry(ctx)
+	if err != nil {
+		return models.PlanetTypeDefinition{}, err
+	}
+	kind, ok := registry.Get(models.PlanetType(name))
+	if !ok {
+		return kind, problems.NotFoundf("Could not find planet type %s.", name)
+	}
+	return kind, nil
+}
+
+// Create validates and stores a new planet type.
+func (s *PlanetTypes) Create(ctx context.Context, kind *models.PlanetTypeDefinition) error {
+	if err := validatePlanetType(kind); err != nil {
+		return err
+	}
+	registry, err := s.Registry(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := registry.Get(kind.Name); ok {
+		return problems.Conflictf("A planet type named %s already exists.", kind.Name)
+	}
+
+	return s.query(ctx).Create(kind).Error
+}
+
+// Update validates and replaces the definition of a stored planet type, the name cannot change.
+// Planets already stored are not validated again, the new ranges apply to their next update.
+func (s *PlanetTypes) Update(ctx context.Context, name string, updatedKind models.PlanetTypeDefinition) (models.PlanetTypeDefinition, error) {
+	kind, err := s.stored(ctx, name)
+	if err != nil {
+		return kind, err
+	}
+	updatedKind.Name = kind.Name
+	if err := validatePlanetType(&updatedKind); err != nil {
+		return kind, err
+	}
+
+	if err := s.query(ctx).Save(&updatedKind).Error; err != nil {
+		return kind, err
+	}
+	return updatedKind, nil
+}
+
+// Delete removes a stored planet type that no planet uses.
+func (s *PlanetTypes) Delete(ctx context.Context, name string) error {
+	kind, err := s.stored(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	var planets int64
+	if err := s.query(ctx).Model(&models.Planet{}).Where("type = ?", kind.Name).Count(&planets).Error; err != nil {
+		return err
+	}
+	if planets > 0 {
+		return problems.Conflictf("Planet type %s is still used by %d planets, change or delete them first.", kind.Name, planets)
+	}
+
+	return s.query(ctx).Delete(&models.PlanetTypeDefinition{}, "name = ?", kind.Name).Error
+}
+
+// stored returns a planet type that may be changed, built-in types are a conflict.
+func (s *PlanetTypes) stored(ctx context.Context, name string) (models.PlanetTypeDefinition, error) {
+	kind, err := s.Get(ctx, name)
+	if err != nil {
+		return kind, err
+	}
+	if kind.BuiltIn {
+		return kind, problems.Conflictf("Planet type %s is built in and cannot be changed.", name)
+	}
+	return kind, nil
+}
+
+// validatePlanetType checks required fields and the ranges and gravity model of a planet type.
+func validatePlanetType(kind *models.PlanetTypeDefinition) error {
+	if err := binding.Validator.ValidateStruct(kind); err != nil {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+	if err := kind.Validate(); err != nil {
+		return problems.Unprocessable(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,512 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kaitou-1412/Go-Space-Voyagers/metrics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Planets implements the planet catalogue operations shared by the REST, gRPC and GraphQL APIs.
+// Failures the caller can act on are returned as *problems.Problem, anything else is an internal error.
+type Planets struct {
+	db    *gorm.DB
+	types *PlanetTypes
+}
+
+func NewPlanets(db *gorm.DB) *Planets {
+	return &Planets{db: db, types: NewPlanetTypes(db)}
+}
+
+// query starts a request-scoped query so conditions never leak between calls.
+func (s *Planets) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// List returns the planets matching the filters, sorting and pagination of params, derived properties included.
+func (s *Planets) List(ctx context.Context, params *queryoperations.QueryParams) ([]models.Planet, error) {
+	if err := params.ValidateSort(&models.DerivedPlanetFilters); err != nil {
+		return nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+	params, err := CanonicalFilters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	planets := []models.Planet{}
+	query := derive(queryoperations.Join(s.query(ctx), params, models.PlanetRelations))
+	if err := queryoperations.Apply(query, params, &models.DerivedPlanetFilters).Find(&planets).Error; err != nil {
+		return nil, err
+	}
+	listed := make([]*models.Planet, len(planets))
+	for i := range planets {
+		listed[i] = &planets[i]
+	}
+	return planets, s.resolve(ctx, listed...)
+}
+
+// Count returns the number of planets matching the filters of params over all pages.
+func (s *Planets) Count(ctx context.Context, params *queryoperations.QueryParams) (int64, error) {
+	params, err := CanonicalFilters(params)
+	if err != nil {
+		return 0, err
+	}
+
+	query := derive(queryoperations.Join(s.query(ctx), params, models.PlanetRelations))
+	return countPlanets(queryoperations.Filter(query, params, &models.DerivedPlanetFilters))
+}
+
+// Each calls fn for every planet matching the filters and sorting of params, reading them one row at a time.
+// Pagination is ignored, fn returning an error stops the iteration.
+func (s *Planets) Each(ctx context.Context, params *queryoperations.QueryParams, fn func(models.Planet) error) error {
+	if err := params.ValidateSort(&models.DerivedPlanetFilters); err != nil {
+		return problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+	params, err := CanonicalFilters(params)
+	if err != nil {
+		return err
+	}
+
+	registry, err := s.types.Registry(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := derive(queryoperations.Join(s.query(ctx), params, models.PlanetRelations))
+	return s.scan(queryoperations.Sort(queryoperations.Filter(query, params, &models.DerivedPlanetFilters), params), registry, fn)
+}
+
+// EachSelected is Each with the pagination of params applied, visiting the planets List would return.
+func (s *Planets) EachSelected(ctx context.Context, params *queryoperations.QueryParams, fn func(models.Planet) error) error {
+	if err := params.ValidateSort(&models.DerivedPlanetFilters); err != nil {
+		return problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+	params, err := CanonicalFilters(params)
+	if err != nil {
+		return err
+	}
+
+	registry, err := s.types.Registry(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := derive(queryoperations.Join(s.query(ctx), params, models.PlanetRelations))
+	return s.scan(queryoperations.Apply(query, params, &models.DerivedPlanetFilters), registry, fn)
+}
+
+// Values returns the non-null values of a numeric field of DerivedPlanetFilters over the planets matching the filters
+// of params, pagination and sorting are ignored.
+func (s *Planets) Values(ctx context.Context, params *queryoperations.QueryParams, field string) ([]float64, error) {
+	if dataType := models.DerivedPlanetFilters[field]; dataType != "int" && dataType != "float" {
+		return nil, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Field %s is not a numeric planet field.", field))
+	}
+	params, err := CanonicalFilters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// a filter without conditions makes Join add the relation of field
+	scoped := *params
+	scoped.Filters = map[string]queryoperations.FilterParam{field: {}}
+	for name, filter := range params.Filters {
+		scoped.Filters[name] = filter
+	}
+	column := queryoperations.Column(field)
+	if expression, derived := models.DerivedSQL[field]; derived {
+		column = clause.Column{Name: expression, Raw: true}
+	}
+	query := derive(queryoperations.Join(s.query(ctx), &scoped, models.PlanetRelations))
+	query = queryoperations.Filter(query, &scoped, &models.DerivedPlanetFilters).Model(&models.Planet{}).
+		Where(clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{column}})
+
+	values := []float64{}
+	err = query.Clauses(clause.Select{Columns: []clause.Column{column}}).Find(&values).Error
+	return values, err
+}
+
+// scan calls fn for every planet of query, one row at a time, with its type resolved by registry.
+func (s *Planets) scan(query *gorm.DB, registry *models.PlanetTypeRegistry, fn func(models.Planet) error) error {
+	rows, err := query.Model(&models.Planet{}).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var planet models.Planet
+		if err := s.db.ScanRows(rows, &planet); err != nil {
+			return err
+		}
+		registry.Resolve(&planet)
+		if err := fn(planet); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Get loads a planet by id, reporting a missing planet as not found.
+func (s *Planets) Get(ctx context.Context, planetId int64) (models.Planet, error) {
+	var planet models.Planet
+	result := s.query(ctx).Limit(1).Find(&planet, planetId)
+	if result.Error != nil {
+		return planet, result.Error
+	}
+	if planet.ID == 0 {
+		return planet, problems.NotFoundf("Could not find planet %d.", planetId)
+	}
+	return planet, s.resolve(ctx, &planet)
+}
+
+// Create validates and stores a new planet, recording a created event.
+func (s *Planets) Create(ctx context.Context, planet *models.Planet) error {
+	if err := s.validate(ctx, planet, 0); err != nil {
+		return err
+	}
+
+	err := s.query(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(planet).Error; err != nil {
+			return err
+		}
+		return record(tx, models.PlanetCreated, *planet)
+	})
+	if err != nil {
+		return err
+	}
+
+	changes.publish()
+	metrics.PlanetsCreated.Inc()
+	return nil
+}
+
+// Replace validates and applies updatedPlanet to the planet with the given id, recording an updated event.
+// Its nil host star and optional measurements clear those of the planet.
+func (s *Planets) Replace(ctx context.Context, planetId int64, updatedPlanet models.Planet) (models.Planet, error) {
+	return s.update(ctx, planetId, updatedPlanet, false)
+}
+
+// Update is Replace for clients that cannot send the host star and optional measurements, such as gRPC, those of the
+// planet are kept where updatedPlanet has none.
+func (s *Planets) Update(ctx context.Context, planetId int64, updatedPlanet models.Planet) (models.Planet, error) {
+	return s.update(ctx, planetId, updatedPlanet, true)
+}
+
+func (s *Planets) update(ctx context.Context, planetId int64, updatedPlanet models.Planet, keep bool) (models.Planet, error) {
+	planet, err := s.Get(ctx, planetId)
+	if err != nil {
+		return planet, err
+	}
+
+	if keep {
+		for _, field := range []struct{ updated, stored **float64 }{
+			{&updatedPlanet.DistanceError, &planet.DistanceError},
+			{&updatedPlanet.RadiusError, &planet.RadiusError},
+			{&updatedPlanet.MassError, &planet.MassError},
+			{&updatedPlanet.SemiMajorAxis, &planet.SemiMajorAxis},
+		} {
+			if *field.updated == nil {
+				*field.updated = *field.stored
+			}
+		}
+		if updatedPlanet.StarID == nil {
+			updatedPlanet.StarID = planet.StarID
+		}
+	}
+
+	if err := s.validate(ctx, &updatedPlanet, planet.ID); err != nil {
+		return planet, err
+	}
+
+	updatedPlanet.ID = planet.ID
+	err = s.query(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&planet).Updates(updatedPlanet).Error; err != nil {
+			return err
+		}
+		// Updates skips nil fields, an update without them clears the host star and the optional measurements
+		nullable := tx.Model(&planet).Select("star_id", "distance_error", "radius_error", "mass_error", "semi_major_axis")
+		if err := nullable.Updates(updatedPlanet).Error; err != nil {
+			return err
+		}
+		return record(tx, models.PlanetUpdated, planet)
+	})
+	if err != nil {
+		return planet, err
+	}
+
+	changes.publish()
+	return planet, nil
+}
+
+// Delete removes the planet with the given id and its moons, recording a deleted event with its last state.
+func (s *Planets) Delete(ctx context.Context, planetId int64) error {
+	planet, err := s.Get(ctx, planetId)
+	if err != nil {
+		return err
+	}
+
+	err = s.query(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Planet{}, planetId).Error; err != nil {
+			return err
+		}
+		if err := deleteMoons(tx, planet.ID); err != nil {
+			return err
+		}
+		return record(tx, models.PlanetDeleted, planet)
+	})
+	if err != nil {
+		return err
+	}
+
+	changes.publish()
+	metrics.PlanetsDeleted.Inc()
+	return nil
+}
+
+// FuelCost estimates the fuel needed to take a crew of crewCapacity to the planet with the given id.
+func (s *Planets) FuelCost(ctx context.Context, planetId int64, crewCapacity int64) (float64, error) {
+	planet, err := s.Get(ctx, planetId)
+	if err != nil {
+		return 0, err
+	}
+	return s.Quote(planet, crewCapacity)
+}
+
+// ExplainFuelCost is Quote with the terms of the quote and warnings about unreliable inputs.
+func (s *Planets) ExplainFuelCost(planet models.Planet, crewCapacity int64) (models.FuelCostBreakdown, error) {
+	if _, err := s.Quote(planet, crewCapacity); err != nil {
+		return models.FuelCostBreakdown{}, err
+	}
+	return planet.ExplainFuelCost(crewCapacity), nil
+}
+
+// MaxMonteCarloSamples bounds the samples of one fuel cost estimate.
+const MaxMonteCarloSamples = 100000
+
+// EstimateFuelCost samples the fuel cost for a crew of crewCapacity to an already loaded planet from its
+// measurement errors. Estimates that do not stay finite are unprocessable.
+func (s *Planets) EstimateFuelCost(planet models.Planet, crewCapacity int64, options models.MonteCarlo) (models.FuelCostEstimate, error) {
+	if options.Samples <= 0 || options.Samples > MaxMonteCarloSamples {
+		return models.FuelCostEstimate{}, problems.BadRequest(problems.InvalidQuery, fmt.Sprintf("Samples should be between 1 and %d.", MaxMonteCarloSamples))
+	}
+	for _, percentile := range options.Percentiles {
+		if !(0 <= percentile && percentile <= 100) {
+			return models.FuelCostEstimate{}, problems.BadRequest(problems.InvalidQuery, "Percentiles should be between 0 and 100.")
+		}
+	}
+	if crewCapacity <= 0 {
+		return models.FuelCostEstimate{}, problems.Unprocessable("Crew capacity should be positive.")
+	}
+
+	estimate := planet.EstimateFuelCost(crewCapacity, options)
+	values := []float64{estimate.Mean, estimate.StdDev}
+	for _, cost := range estimate.Percentiles {
+		values = append(values, cost)
+	}
+	for _, value := range values {
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return models.FuelCostEstimate{}, problems.Unprocessable(fmt.Sprintf("The measurement errors of planet %d are too large to estimate its fuel cost.", planet.ID))
+		}
+	}
+	return estimate, nil
+}
+
+// Quote estimates the fuel needed to take a crew of crewCapacity to an already loaded planet.
+func (s *Planets) Quote(planet models.Planet, crewCapacity int64) (float64, error) {
+	if crewCapacity <= 0 {
+		return 0, problems.Unprocessable("Crew capacity should be positive.")
+	}
+
+	fuelCost := planet.GetFuelCost(crewCapacity)
+	metrics.FuelQuotes.WithLabelValues(string(planet.Type)).Inc()
+	return fuelCost, nil
+}
+
+// Priced returns the planets matching params with the fuel cost of a trip for a crew of crewCapacity.
+// The fuel cost is computed in SQL, so params can filter, sort and paginate on it as fuel_cost.
+func (s *Planets) Priced(ctx context.Context, params *queryoperations.QueryParams, crewCapacity int64) ([]models.PricedPlanet, error) {
+	query, registry, err := s.pricedQuery(ctx, params, crewCapacity)
+	if err != nil {
+		return nil, err
+	}
+	return s.price(queryoperations.Paginate(queryoperations.Sort(query, params), params), registry, crewCapacity)
+}
+
+// CountPriced is Count with fuel_cost filters for a crew of crewCapacity, the total of Priced over all pages.
+func (s *Planets) CountPriced(ctx context.Context, params *queryoperations.QueryParams, crewCapacity int64) (int64, error) {
+	query, _, err := s.pricedQuery(ctx, params, crewCapacity)
+	if err != nil {
+		return 0, err
+	}
+	return countPlanets(query)
+}
+
+// Reachable returns the planets matching the filters of params that a crew of crewCapacity can reach within budget.
+// They are sorted by fuel cost, ties keep the sorting of params, and paginated by params.
+func (s *Planets) Reachable(ctx context.Context, params *queryoperations.QueryParams, crewCapacity int64, budget float64) ([]models.PricedPlanet, error) {
+	query, registry, err := s.reachableQuery(ctx, params, crewCapacity, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	fuelCost := clause.Column{Name: models.FuelCostSQL(crewCapacity, registry.List()), Raw: true}
+	query = query.Order(clause.OrderByColumn{Column: fuelCost})
+	return s.price(queryoperations.Paginate(queryoperations.Sort(query, params), params), registry, crewCapacity)
+}
+
+// CountReachable returns the number of planets Reachable returns over all pages.
+func (s *Planets) CountReachable(ctx context.Context, params *queryoperations.QueryParams, crewCapacity int64, budget float64) (int64, error) {
+	query, _, err := s.reachableQuery(ctx, params, crewCapacity, budget)
+	if err != nil {
+		return 0, err
+	}
+	return countPlanets(query)
+}
+
+// reachableQuery is pricedQuery restricted to the planets within budget.
+func (s *Planets) reachableQuery(ctx context.Context, params *queryoperations.QueryParams, crewCapacity int64, budget float64) (*gorm.DB, *models.PlanetTypeRegistry, error) {
+	if budget < 0 {
+		return nil, nil, problems.Unprocessable("Budget should not be negative.")
+	}
+	query, registry, err := s.pricedQuery(ctx, params, crewCapacity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fuelCost := clause.Column{Name: models.FuelCostSQL(crewCapacity, registry.List()), Raw: true}
+	return query.Where(clause.Lte{Column: fuelCost, Value: budget}), registry, nil
+}
+
+// pricedQuery validates params and filters the planets, with fuel_cost computed for a crew of crewCapacity
+// from the planet types of the returned registry.
+func (s *Planets) pricedQuery(ctx context.Context, params *queryoperations.QueryParams, crewCapacity int64) (*gorm.DB, *models.PlanetTypeRegistry, error) {
+	if crewCapacity <= 0 {
+		return nil, nil, problems.Unprocessable("Crew capacity should be positive.")
+	}
+	if err := params.ValidateSort(&models.PricedPlanetFilters); err != nil {
+		return nil, nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+	params, err := CanonicalFilters(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	registry, err := s.types.Registry(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := derive(queryoperations.Join(s.query(ctx), params, models.PlanetRelations))
+	query = queryoperations.Compute(query, "fuel_cost", models.FuelCostSQL(crewCapacity, registry.List()))
+	return queryoperations.Filter(query, params, &models.PricedPlanetFilters), registry, nil
+}
+
+// price loads the planets of query with their fuel cost, computed again in Go so it matches GetFuelCost exactly.
+func (s *Planets) price(query *gorm.DB, registry *models.PlanetTypeRegistry, crewCapacity int64) ([]models.PricedPlanet, error) {
+	var planets []models.Planet
+	if err := query.Find(&planets).Error; err != nil {
+		return nil, err
+	}
+	priced := make([]models.PricedPlanet, len(planets))
+	for i := range planets {
+		registry.Resolve(&planets[i])
+		priced[i] = models.PricedPlanet{Planet: planets[i], FuelCost: planets[i].GetFuelCost(crewCapacity)}
+	}
+	return priced, nil
+}
+
+// resolve sets the planet type definitions of planets, so stored types apply to their fuel cost and validation.
+func (s *Planets) resolve(ctx context.Context, planets ...*models.Planet) error {
+	registry, err := s.types.Registry(ctx)
+	if err != nil {
+		return err
+	}
+	registry.Resolve(planets...)
+	return nil
+}
+
+// Derive sets the derived properties of planets, loading their host stars.
+func (s *Planets) Derive(ctx context.Context, planets ...*models.Planet) error {
+	var starIds []uint
+	for _, planet := range planets {
+		if planet.StarID != nil {
+			starIds = append(starIds, *planet.StarID)
+		}
+	}
+	stars := map[uint]*models.Star{}
+	if len(starIds) > 0 {
+		var found []models.Star
+		if err := s.query(ctx).Where("id IN ?", starIds).Find(&found).Error; err != nil {
+			return err
+		}
+		for i := range found {
+			stars[found[i].ID] = &found[i]
+		}
+	}
+
+	for _, planet := range planets {
+		var star *models.Star
+		if planet.StarID != nil {
+			star = stars[*planet.StarID]
+		}
+		derived := planet.Derive(star)
+		planet.Derived = &derived
+	}
+	return nil
+}
+
+// countPlanets returns the number of planets of query.
+func countPlanets(query *gorm.DB) (int64, error) {
+	var total int64
+	err := query.Model(&models.Planet{}).Count(&total).Error
+	return total, err
+}
+
+// derive makes the derived properties of models.DerivedSQL available to the filters and sorting of query.
+func derive(query *gorm.DB) *gorm.DB {
+	for field, expression := range models.DerivedSQL {
+		query = queryoperations.Compute(query, field, expression)
+	}
+	return query
+}
+
+// validate checks required fields, catalogue rules, that the host star exists
+// and that no other planet has the same name.
+func (s *Planets) validate(ctx context.Context, planet *models.Planet, planetId uint) error {
+	// the same binding rules the REST API enforces when decoding JSON
+	if err := binding.Validator.ValidateStruct(planet); err != nil {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+
+	if err := s.resolve(ctx, planet); err != nil {
+		return err
+	}
+	if err := planet.Validate(); err != nil {
+		return problems.Unprocessable(err.Error())
+	}
+
+	if planet.StarID != nil {
+		var stars int64
+		if err := s.query(ctx).Model(&models.Star{}).Where("id = ?", *planet.StarID).Count(&stars).Error; err != nil {
+			return err
+		}
+		if stars == 0 {
+			return problems.Unprocessable(fmt.Sprintf("Could not find star %d.", *planet.StarID))
+		}
+	}
+
+	var count int64
+	if err := s.query(ctx).Model(&models.Planet{}).Where("name = ? AND id <> ?", planet.Name, planetId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return problems.Conflictf("A planet named %s already exists.", planet.Name)
+	}
+	return nil
+}
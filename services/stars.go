@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kaitou-1412/Go-Space-Voyagers/metrics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"gorm.io/gorm"
+)
+
+// Stars manages host stars.
+type Stars struct {
+	db *gorm.DB
+}
+
+func NewStars(db *gorm.DB) *Stars {
+	return &Stars{db: db}
+}
+
+func (s *Stars) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// List returns the stars matching the filters, sorting and pagination of params.
+func (s *Stars) List(ctx context.Context, params *queryoperations.QueryParams) ([]models.Star, error) {
+	if err := params.ValidateSort(&models.StarFilters); err != nil {
+		return nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+
+	stars := []models.Star{}
+	err := queryoperations.Apply(s.query(ctx), params, &models.StarFilters).Find(&stars).Error
+	return stars, err
+}
+
+// Get loads a star by id, reporting a missing star as not found.
+func (s *Stars) Get(ctx context.Context, starId int64) (models.Star, error) {
+	var star models.Star
+	result := s.query(ctx).Limit(1).Find(&star, starId)
+	if result.Error != nil {
+		return star, result.Error
+	}
+	if star.ID == 0 {
+		return star, problems.NotFoundf("Could not find star %d.", starId)
+	}
+	return star, nil
+}
+
+// Planets lists the planets hosted by a star, with the filters, sorting and pagination of params.
+func (s *Stars) Planets(ctx context.Context, starId int64, params *queryoperations.QueryParams) ([]models.Planet, error) {
+	if _, err := s.Get(ctx, starId); err != nil {
+		return nil, err
+	}
+	return NewPlanets(s.db).List(ctx, withCondition(params, "star_id", starId))
+}
+
+// Create validates and stores a new star.
+func (s *Stars) Create(ctx context.Context, star *models.Star) error {
+	if err := s.validate(ctx, star, 0); err != nil {
+		return err
+	}
+	return s.query(ctx).Create(star).Error
+}
+
+// Update validates and applies the non-zero fields of updatedStar to the star with the given id.
+func (s *Stars) Update(ctx context.Context, starId int64, updatedStar models.Star) (models.Star, error) {
+	star, err := s.Get(ctx, starId)
+	if err != nil {
+		return star, err
+	}
+	if err := s.validate(ctx, &updatedStar, star.ID); err != nil {
+		return star, err
+	}
+
+	updatedStar.ID = star.ID
+	err = s.query(ctx).Model(&star).Updates(updatedStar).Error
+	return star, err
+}
+
+// Delete removes a star. A star still hosting planets is only removed with cascade, which deletes its planets
+// and their moons in the same transaction, recording their deleted events.
+func (s *Stars) Delete(ctx context.Context, starId int64, cascade bool) error {
+	if _, err := s.Get(ctx, starId); err != nil {
+		return err
+	}
+
+	var planets []models.Planet
+	if err := s.query(ctx).Where("star_id = ?", starId).Find(&planets).Error; err != nil {
+		return err
+	}
+	if len(planets) > 0 && !cascade {
+		return problems.Conflictf("Star %d still hosts %d planets, delete them first or pass cascade=true.", starId, len(planets))
+	}
+
+	err := s.query(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, planet := range planets {
+			if err := tx.Delete(&planet).Error; err != nil {
+				return err
+			}
+			if err := deleteMoons(tx, planet.ID); err != nil {
+				return err
+			}
+			if err := record(tx, models.PlanetDeleted, planet); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&models.Star{}, starId).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(planets) > 0 {
+		changes.publish()
+		metrics.PlanetsDeleted.Add(float64(len(planets)))
+	}
+	return nil
+}
+
+// validate checks required fields, star ranges, that the star system exists and that no other star has the same name.
+func (s *Stars) validate(ctx context.Context, star *models.Star, starId uint) error {
+	if err := binding.Validator.ValidateStruct(star); err != nil {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+	if err := star.Validate(); err != nil {
+		return problems.Unprocessable(err.Error())
+	}
+
+	if star.StarSystemID != nil {
+		var systems int64
+		if err := s.query(ctx).Model(&models.StarSystem{}).Where("id = ?", *star.StarSystemID).Count(&systems).Error; err != nil {
+			return err
+		}
+		if systems == 0 {
+			return problems.Unprocessable(fmt.Sprintf("Could not find star system %d.", *star.StarSystemID))
+		}
+	}
+
+	var count int64
+	if err := s.query(ctx).Model(&models.Star{}).Where("name = ? AND id <> ?", star.Name, starId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return problems.Conflictf("A star named %s already exists.", star.Name)
+	}
+	return nil
+}
+
+// StarSystems manages the systems grouping stars.
+type StarSystems struct {
+	db *gorm.DB
+}
+
+func NewStarSystems(db *gorm.DB) *StarSystems {
+	return &StarSystems{db: db}
+}
+
+func (s *StarSystems) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// List returns the star systems matching the filters, sorting and pagination of params.
+func (s *StarSystems) List(ctx context.Context, params *queryoperations.QueryParams) ([]models.StarSystem, error) {
+	if err := params.ValidateSort(&models.StarSystemFilters); err != nil {
+		return nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+	}
+
+	systems := []models.StarSystem{}
+	err := queryoperations.Apply(s.query(ctx), params, &models.StarSystemFilters).Find(&systems).Error
+	return systems, err
+}
+
+// Get loads a star system by id, reporting a missing system as not found.
+func (s *StarSystems) Get(ctx context.Context, systemId int64) (models.StarSystem, error) {
+	var system models.StarSystem
+	result := s.query(ctx).Limit(1).Find(&system, systemId)
+	if result.Error != nil {
+		return system, result.Error
+	}
+	if system.ID == 0 {
+		return system, problems.NotFoundf("Could not find star system %d.", systemId)
+	}
+	return system, nil
+}
+
+// Stars lists the stars of a system, with the filters, sorting and pagination of params.
+func (s *StarSystems) Stars(ctx context.Context, systemId int64, params *queryoperations.QueryParams) ([]models.Star, error) {
+	if _, err := s.Get(ctx, systemId); err != nil {
+		return nil, err
+	}
+	return NewStars(s.db).List(ctx, withCondition(params, "star_system_id", systemId))
+}
+
+// Create validates and stores a new star system.
+func (s *StarSystems) Create(ctx context.Context, system *models.StarSystem) error {
+	if err := s.validate(ctx, system, 0); err != nil {
+		return err
+	}
+	return s.query(ctx).Create(system).Error
+}
+
+// Update validates and applies the non-zero fields of updatedSystem to the star system with the given id.
+func (s *StarSystems) Update(ctx context.Context, systemId int64, updatedSystem models.StarSystem) (models.StarSystem, error) {
+	system, err := s.Get(ctx, systemId)
+	if err != nil {
+		return system, err
+	}
+	if err := s.validate(ctx, &updatedSystem, system.ID); err != nil {
+		return system, err
+	}
+
+	updatedSystem.ID = system.ID
+	err = s.query(ctx).Model(&system).Updates(updatedSystem).Error
+	return system, err
+}
+
+// Delete removes a star system, its stars are kept and no longer belong to a system.
+func (s *StarSystems) Delete(ctx context.Context, systemId int64) error {
+	if _, err := s.Get(ctx, systemId); err != nil {
+		return err
+	}
+	return s.query(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Star{}).Where("star_system_id = ?", systemId).Update("star_system_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.StarSystem{}, systemId).Error
+	})
+}
+
+// validate checks required fields and that no other system has the same name.
+func (s *StarSystems) validate(ctx context.Context, system *models.StarSystem, systemId uint) error {
+	if err := binding.Validator.ValidateStruct(system); err != nil {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+
+	var count int64
+	if err := s.query(ctx).Model(&models.StarSystem{}).Where("name = ? AND id <> ?", system.Name, systemId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return problems.Conflictf("A star system named %s already exists.", system.Name)
+	}
+	return nil
+}
+
+// withCondition returns a copy of params that also requires field to equal id, leaving the caller's filters untouched.
+func withCondition(params *queryoperations.QueryParams, field string, id int64) *queryoperations.QueryParams {
+	scoped := *params
+	scoped.Filters = make(map[string]queryoperations.FilterParam, len(params.Filters)+1)
+	for name, filter := range params.Filters {
+		scoped.Filters[name] = filter
+	}
+	filter := scoped.Filters[field]
+	filter.Eq = id
+	scoped.Filters[field] = filter
+	return &scoped
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"strconv"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	queryoperations "github.com/kaitou-1412/Go-Space-Voyagers/queryOperations"
+	"github.com/kaitou-1412/Go-Space-Voyagers/units"
+)
+
+// CanonicalFilters returns a copy of params with the unit-suffixed operands of planet measurement filters, such as
+// {"gte": "3 pc"} on distance, converted to the canonical unit of the field. Numbers are left as they are.
+func CanonicalFilters(params *queryoperations.QueryParams) (*queryoperations.QueryParams, error) {
+	converted := *params
+	converted.Filters = make(map[string]queryoperations.FilterParam, len(params.Filters))
+	for field, filter := range params.Filters {
+		canonical, measured := models.PlanetUnits[field]
+		if !measured {
+			converted.Filters[field] = filter
+			continue
+		}
+
+		var err error
+		for _, operand := range []*interface{}{&filter.Eq, &filter.Neq, &filter.Gt, &filter.Gte, &filter.Lt, &filter.Lte} {
+			if text, ok := (*operand).(string); ok && err == nil {
+				*operand, err = convertOperand(text, canonical)
+			}
+		}
+		for _, list := range []*[]string{&filter.In, &filter.NotIn} {
+			*list = append([]string(nil), *list...)
+			for i, text := range *list {
+				if err == nil {
+					var value interface{}
+					value, err = convertOperand(text, canonical)
+					(*list)[i] = strconv.FormatFloat(value.(float64), 'f', -1, 64)
+				}
+			}
+		}
+		if err != nil {
+			return nil, problems.BadRequest(problems.InvalidQuery, err.Error())
+		}
+		converted.Filters[field] = filter
+	}
+	return &converted, nil
+}
+
+func convertOperand(text string, canonical units.Unit) (interface{}, error) {
+	value, err := units.Parse(text, canonical)
+	if err != nil {
+		return 0.0, err
+	}
+	return value, nil
+}
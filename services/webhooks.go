@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/problems"
+	"gorm.io/gorm"
+)
+
+// Webhooks manages webhook subscriptions and their deliveries.
+type Webhooks struct {
+	db *gorm.DB
+}
+
+func NewWebhooks(db *gorm.DB) *Webhooks {
+	return &Webhooks{db: db}
+}
+
+func (s *Webhooks) query(ctx context.Context) *gorm.DB {
+	return s.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+}
+
+// List returns every webhook subscription.
+func (s *Webhooks) List(ctx context.Context) ([]models.Webhook, error) {
+	webhooks := []models.Webhook{}
+	err := s.query(ctx).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// Get loads a webhook by id, reporting a missing webhook as not found.
+func (s *Webhooks) Get(ctx context.Context, webhookId int64) (models.Webhook, error) {
+	var webhook models.Webhook
+	result := s.query(ctx).Limit(1).Find(&webhook, webhookId)
+	if result.Error != nil {
+		return webhook, result.Error
+	}
+	if webhook.ID == 0 {
+		return webhook, problems.NotFoundf("Could not find webhook %d.", webhookId)
+	}
+	return webhook, nil
+}
+
+// Create validates and stores a new webhook subscription.
+func (s *Webhooks) Create(ctx context.Context, webhook *models.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	return s.query(ctx).Create(webhook).Error
+}
+
+// Update replaces the URL, event types and secret of a webhook, pending deliveries use the new settings.
+func (s *Webhooks) Update(ctx context.Context, webhookId int64, updatedWebhook models.Webhook) (models.Webhook, error) {
+	webhook, err := s.Get(ctx, webhookId)
+	if err != nil {
+		return webhook, err
+	}
+	if err := validateWebhook(&updatedWebhook); err != nil {
+		return webhook, err
+	}
+
+	webhook.URL = updatedWebhook.URL
+	webhook.Events = updatedWebhook.Events
+	webhook.Secret = updatedWebhook.Secret
+	err = s.query(ctx).Select("URL", "Events", "Secret").Updates(&webhook).Error
+	return webhook, err
+}
+
+// Delete removes a webhook together with its queued and past deliveries.
+func (s *Webhooks) Delete(ctx context.Context, webhookId int64) error {
+	if _, err := s.Get(ctx, webhookId); err != nil {
+		return err
+	}
+	return s.query(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhookId).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Webhook{}, webhookId).Error
+	})
+}
+
+// Deliveries lists the deliveries of a webhook, newest first, optionally only those with the given status.
+func (s *Webhooks) Deliveries(ctx context.Context, webhookId int64, status models.DeliveryStatus) ([]models.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, webhookId); err != nil {
+		return nil, err
+	}
+	switch status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead:
+	default:
+		return nil, problems.BadRequest(problems.InvalidQuery, "Unknown delivery status "+string(status)+".")
+	}
+
+	query := s.query(ctx).Where("webhook_id = ?", webhookId)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	deliveries := []models.WebhookDelivery{}
+	err := query.Preload("Event").Order("id DESC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Redeliver queues a delivery of the webhook for an immediate attempt with a fresh retry budget,
+// whether it was delivered or dead.
+func (s *Webhooks) Redeliver(ctx context.Context, webhookId int64, deliveryId int64) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	result := s.query(ctx).Where("webhook_id = ?", webhookId).Limit(1).Find(&delivery, deliveryId)
+	if result.Error != nil {
+		return delivery, result.Error
+	}
+	if delivery.ID == 0 {
+		return delivery, problems.NotFoundf("Could not find delivery %d of webhook %d.", deliveryId, webhookId)
+	}
+
+	err := s.query(ctx).Model(&delivery).Omit("Webhook", "Event").Updates(map[string]interface{}{
+		"status":          models.DeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	}).Error
+	if err != nil {
+		return delivery, err
+	}
+
+	changes.publish()
+	return delivery, nil
+}
+
+// validateWebhook checks the binding rules, that the URL is http(s) and that the event types exist.
+func validateWebhook(webhook *models.Webhook) error {
+	if err := binding.Validator.ValidateStruct(webhook); err != nil {
+		return problems.Unprocessable("Could not parse request data.")
+	}
+	if parsed, err := url.Parse(webhook.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return problems.Unprocessable("Webhook URL must use http or https.")
+	}
+	for _, eventType := range webhook.Events {
+		switch eventType {
+		case models.PlanetCreated, models.PlanetUpdated, models.PlanetDeleted:
+		default:
+			return problems.Unprocessable("Unknown event type " + string(eventType) + ".")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package units converts planet measurements between units of length and mass.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/physics"
+)
+
+// Dimension is the physical quantity a unit measures, only units of the same dimension convert into each other.
+type Dimension string
+
+const (
+	Length Dimension = "length"
+	Mass   Dimension = "mass"
+)
+
+// Unit is a unit of measurement, SI is its size in metres or kilograms.
+type Unit struct {
+	Symbol    string
+	Name      string
+	Dimension Dimension
+	SI        float64
+}
+
+var (
+	LightYear        = Unit{Symbol: "ly", Name: "light years", Dimension: Length, SI: 9.4607304725808e15}
+	Parsec           = Unit{Symbol: "pc", Name: "parsecs", Dimension: Length, SI: 3.0856775814913673e16}
+	AstronomicalUnit = Unit{Symbol: "au", Name: "astronomical units", Dimension: Length, SI: physics.AU}
+	Kilometre        = Unit{Symbol: "km", Name: "kilometres", Dimension: Length, SI: 1e3}
+	EarthRadius      = Unit{Symbol: "Re", Name: "Earth radii", Dimension: Length, SI: physics.EarthRadius}
+	JupiterRadius    = Unit{Symbol: "Rj", Name: "Jupiter radii", Dimension: Length, SI: 6.9911e7}
+	EarthMass        = Unit{Symbol: "Me", Name: "Earth masses", Dimension: Mass, SI: physics.EarthMass}
+	JupiterMass      = Unit{Symbol: "Mj", Name: "Jupiter masses", Dimension: Mass, SI: 1.89813e27}
+)
+
+// All lists the known units, symbols are unique regardless of case.
+var All = []Unit{LightYear, Parsec, AstronomicalUnit, Kilometre, EarthRadius, JupiterRadius, EarthMass, JupiterMass}
+
+// Error describes a value or unit that cannot be read or converted.
+type Error struct {
+	Message string
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+// Lookup finds a unit by its symbol, ignoring case.
+func Lookup(symbol string) (Unit, error) {
+	for _, unit := range All {
+		if strings.EqualFold(unit.Symbol, symbol) {
+			return unit, nil
+		}
+	}
+	return Unit{}, &Error{Message: fmt.Sprintf("Unknown unit %s.", symbol)}
+}
+
+// Convert expresses value, measured in unit, in the unit to.
+func (unit Unit) Convert(value float64, to Unit) float64 {
+	if unit == to {
+		return value
+	}
+	return value * unit.SI / to.SI
+}
+
+// Lookup finds a unit by its symbol like the package Lookup, and checks it measures the same dimension as unit.
+func (unit Unit) Lookup(symbol string) (Unit, error) {
+	found, err := Lookup(symbol)
+	if err != nil {
+		return found, err
+	}
+	if found.Dimension != unit.Dimension {
+		return found, &Error{Message: fmt.Sprintf("Unit %s is not a %s.", found.Symbol, unit.Dimension)}
+	}
+	return found, nil
+}
+
+// Parse reads a number followed by a unit symbol, such as "3.2 pc" or "1.1Rj", and returns it in canonical.
+// A number without a unit is taken to be in canonical already.
+func Parse(text string, canonical Unit) (float64, error) {
+	text = strings.TrimSpace(text)
+	split := len(text)
+	for split > 0 && !strings.ContainsRune("0123456789.", rune(text[split-1])) {
+		split--
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(text[:split]), 64)
+	if err != nil || math.IsInf(value, 0) {
+		return 0, &Error{Message: fmt.Sprintf("Could not parse %q as a %s.", text, canonical.Dimension)}
+	}
+	if split == len(text) {
+		return value, nil
+	}
+
+	unit, err := canonical.Lookup(strings.TrimSpace(text[split:]))
+	if err != nil {
+		return 0, err
+	}
+	return unit.Convert(value, canonical), nil
+}
+
+// Range is an open interval of valid values, in Unit.
+type Range struct {
+	Min, Max float64
+	Unit     Unit
+}
+
+// Contains reports whether value, measured in unit, lies strictly within the range.
+func (r Range) Contains(value float64, unit Unit) bool {
+	value = unit.Convert(value, r.Unit)
+	return r.Min < value && value < r.Max
+}
+
+// String reads like "between 10 and 1000 light years".
+func (r Range) String() string {
+	return fmt.Sprintf("between %g and %g %s", r.Min, r.Max, r.Unit.Name)
+}
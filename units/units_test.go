@@ -0,0 +1,48 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text      string
+		canonical Unit
+		expected  float64
+		err       string
+	}{
+		{"12", LightYear, 12, ""},
+		{"1 pc", LightYear, 3.2615637771674337, ""},
+		{"2.5PC", LightYear, 8.153909442918584, ""},
+		{"1 Rj", EarthRadius, 10.973316590802072, ""},
+		{" 6371 km ", EarthRadius, 1, ""},
+		{"1 mj", EarthMass, 317.8276012189813, ""},
+		{"1 au", LightYear, 1.5812507409819728e-05, ""},
+		{"3 Mj", LightYear, 0, "Unit Mj is not a length."},
+		{"3 furlongs", LightYear, 0, "Unknown unit furlongs."},
+		{"pc", LightYear, 0, `Could not parse "pc" as a length.`},
+		{"", EarthMass, 0, `Could not parse "" as a mass.`},
+	}
+	for _, test := range tests {
+		value, err := Parse(test.text, test.canonical)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.text)
+			continue
+		}
+		assert.NoError(t, err, test.text)
+		assert.InDelta(t, test.expected, value, 1e-12*test.expected, test.text)
+	}
+}
+
+func TestConvertAndRange(t *testing.T) {
+	assert.Equal(t, 42.0, Parsec.Convert(42, Parsec))
+	assert.InDelta(t, 1, LightYear.Convert(Parsec.Convert(1, LightYear), Parsec), 1e-15)
+
+	distances := Range{Min: 10, Max: 1000, Unit: LightYear}
+	assert.True(t, distances.Contains(100, Parsec))
+	assert.False(t, distances.Contains(400, Parsec))
+	assert.False(t, distances.Contains(10, LightYear))
+	assert.Equal(t, "between 10 and 1000 light years", distances.String())
+}
@@ -0,0 +1,243 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/metrics"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"gorm.io/gorm"
+)
+
+// batchSize is the number of due deliveries attempted per pass.
+const batchSize = 50
+
+// recordTimeout bounds writing the outcome of an attempt once the dispatcher is stopping.
+const recordTimeout = 5 * time.Second
+
+// Dispatcher sends the pending deliveries of the webhook outbox, retrying failures with exponential backoff
+// until they succeed or run out of attempts and are dead-lettered. Several dispatchers may share a database.
+type Dispatcher struct {
+	db           *gorm.DB
+	client       *http.Client
+	allowed      []netip.Prefix
+	maxAttempts  int
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithHTTPClient replaces the default http.Client, its timeout bounds every attempt.
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// WithAllowedNetworks lets deliveries reach the given networks, such as 10.0.0.0/8. Only public addresses are
+// dialled otherwise, so that webhooks cannot target the hosts next to the dispatcher.
+func WithAllowedNetworks(networks ...netip.Prefix) Option {
+	return func(d *Dispatcher) {
+		d.allowed = append(d.allowed, networks...)
+	}
+}
+
+// WithRetries dead-letters deliveries after maxAttempts failed attempts, waiting base, 2*base, 4*base... up to max between them.
+func WithRetries(maxAttempts int, base time.Duration, max time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.baseBackoff = base
+		d.maxBackoff = max
+	}
+}
+
+// WithPollInterval sets how often the outbox is checked for retries and for changes made by other instances.
+func WithPollInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.pollInterval = interval
+	}
+}
+
+// WithClock replaces time.Now, e.g. to test backoff without waiting.
+func WithClock(now func() time.Time) Option {
+	return func(d *Dispatcher) {
+		d.now = now
+	}
+}
+
+func NewDispatcher(db *gorm.DB, options ...Option) *Dispatcher {
+	d := &Dispatcher{
+		db:           db,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		maxAttempts:  8,
+		baseBackoff:  10 * time.Second,
+		maxBackoff:   time.Hour,
+		pollInterval: time.Second,
+		now:          time.Now,
+	}
+	for _, option := range options {
+		option(d)
+	}
+	d.client = guard(d.client, d.allowed)
+	return d
+}
+
+// Run delivers due deliveries until ctx is done, as soon as this process records events and on every poll interval.
+func (d *Dispatcher) Run(ctx context.Context) {
+	signal, unsubscribe := services.NewEvents(d.db).Subscribe()
+	defer unsubscribe()
+	poll := time.NewTicker(d.pollInterval)
+	defer poll.Stop()
+
+	for {
+		for {
+			attempted, err := d.DeliverDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "webhook dispatch failed", slog.String("error", err.Error()))
+			}
+			if err != nil || attempted < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-signal:
+		case <-poll.C:
+		}
+	}
+}
+
+// DeliverDue attempts every pending delivery whose next attempt is due and returns how many were attempted.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	db := d.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+	now := d.now()
+
+	var due []models.WebhookDelivery
+	err := db.Preload("Webhook").Preload("Event").
+		Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+		Order("next_attempt_at").Limit(batchSize).Find(&due).Error
+	if err != nil {
+		return 0, err
+	}
+
+	attempted := 0
+	for _, delivery := range due {
+		if err := ctx.Err(); err != nil {
+			return attempted, err
+		}
+		// claim the delivery for the length of one attempt so other dispatchers skip it
+		claim := db.Model(&models.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at <= ?", delivery.ID, models.DeliveryPending, now).
+			Update("next_attempt_at", now.Add(d.client.Timeout+time.Minute))
+		if claim.Error != nil {
+			return attempted, claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			continue
+		}
+
+		attempted++
+		// a started attempt is finished and recorded even when ctx is cancelled meanwhile, the client timeout
+		// bounds the request and recordTimeout the update, otherwise it would be retried after its claim expires
+		outcome := d.attempt(context.WithoutCancel(ctx), delivery)
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+		err := d.db.Session(&gorm.Session{NewDB: true, Context: recordCtx}).Model(&delivery).Omit("Webhook", "Event").Updates(outcome).Error
+		cancel()
+		if err != nil {
+			return attempted, err
+		}
+	}
+	return attempted, nil
+}
+
+// attempt sends one delivery and returns the columns recording its outcome.
+func (d *Dispatcher) attempt(ctx context.Context, delivery models.WebhookDelivery) map[string]interface{} {
+	now := d.now()
+	attempts := delivery.Attempts + 1
+	statusCode, err := d.send(ctx, delivery)
+	if err == nil {
+		metrics.WebhookDeliveries.WithLabelValues("delivered").Inc()
+		return map[string]interface{}{
+			"status":           models.DeliveryDelivered,
+			"attempts":         attempts,
+			"last_status_code": statusCode,
+			"last_error":       "",
+			"delivered_at":     now,
+		}
+	}
+
+	outcome := map[string]interface{}{
+		"attempts":         attempts,
+		"last_status_code": statusCode,
+		"last_error":       err.Error(),
+	}
+	if attempts >= d.maxAttempts {
+		metrics.WebhookDeliveries.WithLabelValues("dead").Inc()
+		slog.WarnContext(ctx, "webhook delivery dead-lettered", slog.Uint64("delivery_id", uint64(delivery.ID)), slog.String("error", err.Error()))
+		outcome["status"] = models.DeliveryDead
+	} else {
+		metrics.WebhookDeliveries.WithLabelValues("retry").Inc()
+		outcome["next_attempt_at"] = now.Add(d.backoff(attempts))
+	}
+	return outcome
+}
+
+// send posts the signed event to the webhook, any status other than 2xx is a failure.
+func (d *Dispatcher) send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	if delivery.Webhook.ID == 0 {
+		return 0, fmt.Errorf("webhook %d no longer exists", delivery.WebhookID)
+	}
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "go-space-voyagers-webhooks")
+	request.Header.Set(EventHeader, string(delivery.Event.Type))
+	request.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, Sign(delivery.Webhook.Secret, timestamp, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// drain a bounded amount so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// backoff is the wait after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.baseBackoff
+	for i := 1; i < attempts && wait < d.maxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.maxBackoff)
+}
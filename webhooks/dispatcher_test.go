@@ -0,0 +1,247 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaitou-1412/Go-Space-Voyagers/database"
+	"github.com/kaitou-1412/Go-Space-Voyagers/models"
+	"github.com/kaitou-1412/Go-Space-Voyagers/services"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const secret = "0123456789abcdef"
+
+// loopback lets the dispatcher reach the httptest servers.
+var loopback = WithAllowedNetworks(netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128"))
+
+// receiver records the deliveries it accepts and answers with status.
+type receiver struct {
+	mu         sync.Mutex
+	status     int
+	events     []string
+	signatures []error
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, req.Header.Get(EventHeader))
+	r.signatures = append(r.signatures, Verify(secret, req.Header.Get(SignatureHeader), req.Header.Get(TimestampHeader), body, time.Minute))
+	w.WriteHeader(r.status)
+}
+
+func setupDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(database.Models...); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestDeliveries(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	target := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	webhookService := services.NewWebhooks(db)
+	all := models.Webhook{URL: server.URL, Secret: secret}
+	deletions := models.Webhook{URL: server.URL + "/deleted", Events: []models.PlanetEventType{models.PlanetDeleted}, Secret: secret}
+	for _, webhook := range []*models.Webhook{&all, &deletions} {
+		if err := webhookService.Create(ctx, webhook); err != nil {
+			t.Fatalf("Failed to create webhook: %v", err)
+		}
+	}
+
+	planetService := services.NewPlanets(db)
+	planet := models.Planet{Name: "Pluto", Description: "A small planet", Distance: 50, Radius: 2, Mass: 2, Type: models.Terrestrial}
+	if err := planetService.Create(ctx, &planet); err != nil {
+		t.Fatalf("Failed to create planet: %v", err)
+	}
+	if err := planetService.Delete(ctx, int64(planet.ID)); err != nil {
+		t.Fatalf("Failed to delete planet: %v", err)
+	}
+
+	dispatcher := NewDispatcher(db, loopback)
+	attempted, err := dispatcher.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempted)
+	assert.Equal(t, []string{"created", "deleted", "deleted"}, target.events)
+	assert.Equal(t, []error{nil, nil, nil}, target.signatures)
+
+	delivered, _ := webhookService.Deliveries(ctx, int64(all.ID), models.DeliveryDelivered)
+	if assert.Len(t, delivered, 2) {
+		assert.Equal(t, 1, delivered[0].Attempts)
+		assert.Equal(t, http.StatusNoContent, delivered[0].LastStatusCode)
+		assert.NotNil(t, delivered[0].DeliveredAt)
+		assert.Equal(t, "Pluto", delivered[0].Event.Planet.Name)
+	}
+
+	// nothing is due any more
+	attempted, err = dispatcher.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+}
+
+func TestRetriesAndDeadLetters(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	target := &receiver{status: http.StatusInternalServerError}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	webhookService := services.NewWebhooks(db)
+	webhook := models.Webhook{URL: server.URL, Secret: secret}
+	webhookService.Create(ctx, &webhook)
+	planet := models.Planet{Name: "Pluto", Description: "A small planet", Distance: 50, Radius: 2, Mass: 2, Type: models.Terrestrial}
+	if err := services.NewPlanets(db).Create(ctx, &planet); err != nil {
+		t.Fatalf("Failed to create planet: %v", err)
+	}
+
+	now := time.Now()
+	dispatcher := NewDispatcher(db, loopback, WithRetries(3, time.Minute, 90*time.Second), WithClock(func() time.Time { return now }))
+
+	// attempts are spaced by the backoff: 1 minute, then 2 minutes capped to 90 seconds
+	for _, step := range []struct {
+		advance  time.Duration
+		expected int
+	}{
+		{0, 1},
+		{59 * time.Second, 0},
+		{time.Second, 1},
+		{89 * time.Second, 0},
+		{time.Second, 1},
+		{time.Hour, 0},
+	} {
+		now = now.Add(step.advance)
+		attempted, err := dispatcher.DeliverDue(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, step.expected, attempted, "after %s", step.advance)
+	}
+
+	dead, err := webhookService.Deliveries(ctx, int64(webhook.ID), models.DeliveryDead)
+	if !assert.NoError(t, err) || !assert.Len(t, dead, 1) {
+		return
+	}
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, dead[0].LastStatusCode)
+	assert.Equal(t, "unexpected status 500", dead[0].LastError)
+
+	// a redelivered dead letter gets a fresh retry budget
+	target.status = http.StatusOK
+	if _, err := webhookService.Redeliver(ctx, int64(webhook.ID), int64(dead[0].ID)); err != nil {
+		t.Fatalf("Failed to redeliver: %v", err)
+	}
+	dispatcher = NewDispatcher(db, loopback)
+	attempted, err := dispatcher.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	delivered, _ := webhookService.Deliveries(ctx, int64(webhook.ID), models.DeliveryDelivered)
+	if assert.Len(t, delivered, 1) {
+		assert.Equal(t, 1, delivered[0].Attempts)
+	}
+}
+
+func TestStopDuringAttempt(t *testing.T) {
+	db := setupDB(t)
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	// the dispatcher is stopped while the receiver handles the first delivery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stop()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhookService := services.NewWebhooks(db)
+	webhook := models.Webhook{URL: server.URL, Secret: secret}
+	webhookService.Create(context.Background(), &webhook)
+	for _, name := range []string{"Pluto", "Kepler"} {
+		planet := models.Planet{Name: name, Description: "A small planet", Distance: 50, Radius: 2, Mass: 2, Type: models.Terrestrial}
+		if err := services.NewPlanets(db).Create(context.Background(), &planet); err != nil {
+			t.Fatalf("Failed to create planet: %v", err)
+		}
+	}
+
+	attempted, err := NewDispatcher(db, loopback).DeliverDue(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempted, "no new attempt starts once stopped")
+	delivered, _ := webhookService.Deliveries(context.Background(), int64(webhook.ID), models.DeliveryDelivered)
+	assert.Len(t, delivered, 1, "the attempt in flight records its outcome")
+}
+
+func TestNonPublicAddresses(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	target := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(target)
+	defer server.Close()
+
+	webhookService := services.NewWebhooks(db)
+	webhook := models.Webhook{URL: server.URL, Secret: secret}
+	webhookService.Create(ctx, &webhook)
+	planet := models.Planet{Name: "Pluto", Description: "A small planet", Distance: 50, Radius: 2, Mass: 2, Type: models.Terrestrial}
+	if err := services.NewPlanets(db).Create(ctx, &planet); err != nil {
+		t.Fatalf("Failed to create planet: %v", err)
+	}
+
+	// without allowed networks the loopback receiver is never dialled
+	attempted, err := NewDispatcher(db).DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Empty(t, target.events)
+	pending, _ := webhookService.Deliveries(ctx, int64(webhook.ID), models.DeliveryPending)
+	if assert.Len(t, pending, 1) {
+		assert.Contains(t, pending[0].LastError, "refusing to deliver to the non-public address 127.0.0.1")
+	}
+
+	for address, public := range map[string]bool{
+		"93.184.215.14":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.1": false,
+	} {
+		assert.Equal(t, public, isPublic(netip.MustParseAddr(address)), address)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	timestamp := "1700000000"
+	signature := Sign(secret, timestamp, body)
+	assert.Equal(t, "sha256=", signature[:7])
+
+	sent := time.Unix(1700000000, 0)
+	tolerance := time.Since(sent) + time.Minute
+	assert.NoError(t, Verify(secret, signature, timestamp, body, tolerance))
+	assert.Error(t, Verify(secret, signature, timestamp, []byte(`{"id":2}`), tolerance))
+	assert.Error(t, Verify("another secret!!", signature, timestamp, body, tolerance))
+	assert.Error(t, Verify(secret, signature, timestamp, body, time.Minute))
+	assert.Error(t, Verify(secret, signature, "yesterday", body, tolerance))
+}
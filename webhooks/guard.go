@@ -0,0 +1,77 @@
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// reservedNetworks are not reachable on the internet although net/netip counts them as global unicast.
+var reservedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// isPublic reports whether ip is an internet address, rather than a loopback, private, link-local or reserved one.
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// guard returns a copy of client that only connects to public addresses or to the allowed networks. The resolved
+// address is checked when dialling, so host names and redirects cannot lead anywhere else. Proxies are not used, as
+// they would be dialled instead of the webhook. Clients with a RoundTripper other than *http.Transport are returned
+// as they are.
+func guard(client *http.Client, allowed []netip.Prefix) *http.Client {
+	roundTripper := client.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	transport, ok := roundTripper.(*http.Transport)
+	if !ok {
+		return client
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			ip := addrPort.Addr().Unmap()
+			if isPublic(ip) {
+				return nil
+			}
+			for _, prefix := range allowed {
+				if prefix.Contains(ip) {
+					return nil
+				}
+			}
+			return fmt.Errorf("refusing to deliver to the non-public address %s", ip)
+		},
+	}
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	guarded := *client
+	guarded.Transport = transport
+	return &guarded
+}
@@ -0,0 +1,46 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every delivery.
+const (
+	EventHeader     = "X-Voyagers-Event"
+	DeliveryHeader  = "X-Voyagers-Delivery"
+	TimestampHeader = "X-Voyagers-Timestamp"
+	SignatureHeader = "X-Voyagers-Signature"
+)
+
+// Sign returns the signature header value for a body sent at timestamp (Unix seconds):
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a received delivery, rejecting bad signatures and timestamps further than tolerance from now,
+// which stops replays of captured deliveries.
+func Verify(secret string, signature string, timestamp string, body []byte, tolerance time.Duration) error {
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid webhook timestamp")
+	}
+	if math.Abs(time.Since(time.Unix(sent, 0)).Seconds()) > tolerance.Seconds() {
+		return errors.New("webhook timestamp outside the tolerance")
+	}
+	if !strings.HasPrefix(signature, "sha256=") || !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return errors.New("invalid webhook signature")
+	}
+	return nil
+}